@@ -0,0 +1,12 @@
+variable "confirmation_code" {
+  type      = string
+  sensitive = true
+}
+
+# Invoke with: terraform apply -invoke=action.sda_user_confirm.contractor
+action "sda_user_confirm" "contractor" {
+  config {
+    username          = "jane.doe@contractor.example.com"
+    confirmation_code = var.confirmation_code
+  }
+}
@@ -0,0 +1,23 @@
+locals {
+  contractor_email = "jane.doe@contractor.example.com"
+}
+
+resource "sda_user" "contractor" {
+  first_name = "Jane"
+  last_name  = "Doe"
+  email      = local.contractor_email
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.sda_user_password_reset.contractor]
+    }
+  }
+}
+
+# Sends the contractor a code to choose their password.
+action "sda_user_password_reset" "contractor" {
+  config {
+    username = local.contractor_email
+  }
+}
@@ -0,0 +1,7 @@
+data "sda_effective_permissions" "operator" {
+  user_id = sda_user.operator.user_id
+}
+
+output "operator_allow_sets" {
+  value = data.sda_effective_permissions.operator.resource_groups
+}
@@ -0,0 +1,18 @@
+data "sda_federated_users" "all" {}
+
+# Adopt the federated users. Their names and email address are left unset and
+# follow the identity provider.
+import {
+  for_each = data.sda_federated_users.all.user_ids_by_email
+  to       = sda_user.federated[each.key]
+  identity = {
+    user_id = each.value
+  }
+}
+
+resource "sda_user" "federated" {
+  for_each = data.sda_federated_users.all.user_ids_by_email
+
+  source = "SAML"
+  title  = "Contractor"
+}
@@ -0,0 +1,35 @@
+# Corporate baseline shared by all plants.
+data "sda_policy_document" "baseline" {
+  statement {
+    name        = "read-assets"
+    action_sets = ["read"]
+    resources   = ["assets:*"]
+  }
+}
+
+# Plant role that extends the baseline with write access to its own devices.
+data "sda_policy_document" "plant_1" {
+  source_policy_documents = [data.sda_policy_document.baseline.json]
+
+  statement {
+    name               = "manage-devices"
+    description        = "Manage the devices and projects of plant 1"
+    action_sets        = ["write", "download"]
+    asset_types        = ["DEVICE", "PROJECT"]
+    resource_group_ids = [sda_resource_group.plant_1.group_id]
+  }
+}
+
+resource "sda_role" "plant_1" {
+  name = "plant-1-engineer"
+
+  dynamic "policy" {
+    for_each = data.sda_policy_document.plant_1.policies
+    content {
+      name        = policy.value.name
+      actions     = policy.value.actions
+      resources   = policy.value.resources
+      description = policy.value.description
+    }
+  }
+}
@@ -0,0 +1,7 @@
+data "sda_role_members" "admins" {
+  user_role_id = sda_role.admin.user_role_id
+}
+
+output "admin_emails" {
+  value = data.sda_role_members.admins.users[*].email
+}
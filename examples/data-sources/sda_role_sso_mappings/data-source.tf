@@ -0,0 +1,8 @@
+# Roles without members are not discovered automatically and must be listed.
+data "sda_role_sso_mappings" "all" {
+  user_role_ids = [sda_role.auditor.user_role_id]
+}
+
+output "sso_conflicts" {
+  value = data.sda_role_sso_mappings.all.conflicts
+}
@@ -0,0 +1,8 @@
+data "sda_user" "colleague" {
+  email = "jane.doe@example.com"
+}
+
+resource "sda_user_role_association" "colleague_operator" {
+  user_id      = data.sda_user.colleague.user_id
+  user_role_id = sda_role.operator.user_role_id
+}
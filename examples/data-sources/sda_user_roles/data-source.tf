@@ -0,0 +1,7 @@
+data "sda_user_roles" "alice" {
+  user_id = sda_user.alice.user_id
+}
+
+output "alice_roles" {
+  value = data.sda_user_roles.alice.roles[*].name
+}
@@ -0,0 +1,11 @@
+# Users of the tenant that have not signed in for 90 days, for access reviews.
+data "sda_users" "stale" {
+  source       = "SDA"
+  inactive_for = "2160h"
+}
+
+output "stale_users" {
+  value = {
+    for u in data.sda_users.stale.users : u.email => u.last_login_timestamp
+  }
+}
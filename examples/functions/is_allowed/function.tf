@@ -0,0 +1,10 @@
+check "operator_cannot_delete_devices" {
+  assert {
+    condition = !provider::sda::is_allowed(
+      data.sda_effective_permissions.operator.json,
+      "assets:DeleteDevice",
+      "assets:resource_group/${sda_resource_group.plant_1.group_id}/device/*",
+    )
+    error_message = "Operators must not be able to delete devices of plant 1."
+  }
+}
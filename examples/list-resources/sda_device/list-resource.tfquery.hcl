@@ -0,0 +1,10 @@
+# List every device of a resource group and generate configuration for it
+# with `terraform query -generate-config-out=devices.tf`.
+list "sda_device" "line_1" {
+  provider         = sda
+  include_resource = true
+
+  config {
+    group_id = "3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d"
+  }
+}
@@ -0,0 +1,4 @@
+list "sda_gateway" "all" {
+  provider         = sda
+  include_resource = true
+}
@@ -0,0 +1,11 @@
+# List the documents linked to a device.
+list "sda_link" "plc_01_documents" {
+  provider         = sda
+  include_resource = true
+
+  config {
+    source_type      = "DEVICE"
+    source_id        = "3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d"
+    destination_type = "DOCUMENT"
+  }
+}
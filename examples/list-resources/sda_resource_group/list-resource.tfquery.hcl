@@ -0,0 +1,8 @@
+# List the resource groups below a parent group.
+list "sda_resource_group" "plant_a" {
+  provider = sda
+
+  config {
+    parent_group_id = "3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d"
+  }
+}
@@ -0,0 +1,6 @@
+# Import by device ID
+terraform import sda_device.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Import by name, optionally qualified with the resource group name
+terraform import sda_device.example device:plc-01
+terraform import sda_device.example group:line-1/device:plc-01
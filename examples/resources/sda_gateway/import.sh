@@ -0,0 +1,6 @@
+# Import by gateway ID
+terraform import sda_gateway.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Import by name, optionally qualified with the resource group name
+terraform import sda_gateway.example gateway:edge-01
+terraform import sda_gateway.example group:line-1/gateway:edge-01
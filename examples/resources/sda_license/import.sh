@@ -0,0 +1,6 @@
+# Import by license ID
+terraform import sda_license.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Import by name, optionally qualified with the resource group name
+terraform import sda_license.example license:runtime
+terraform import sda_license.example group:line-1/license:runtime
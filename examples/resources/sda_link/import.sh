@@ -0,0 +1,3 @@
+# Import format: source_type/source_id/destination_type/destination_id
+# A "/" inside an ID can be written as %2F when the ID is otherwise ambiguous.
+terraform import sda_link.example DEVICE/3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d/DOCUMENT/8c2e7b1a-5d4f-4a3e-9b6c-1f0d2e3a4b5c
@@ -0,0 +1,6 @@
+# Import by project ID
+terraform import sda_project.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Import by name, optionally qualified with the resource group name
+terraform import sda_project.example project:line-control
+terraform import sda_project.example group:line-1/project:line-control
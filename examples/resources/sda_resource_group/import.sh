@@ -0,0 +1,6 @@
+# Import by group ID
+terraform import sda_resource_group.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Import by name, optionally qualified with the parent group name
+terraform import sda_resource_group.example group:line-1
+terraform import sda_resource_group.example group:plant-a/group:line-1
@@ -0,0 +1,4 @@
+terraform import sda_role.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Roles of another tenant than the provider tenant are prefixed with tenant:<tenant_id>/
+terraform import 'sda_role.plant_auditor["5b1e9c3a-7d2f-4e8a-a6b0-3c4d5e6f7a8b"]' tenant:5b1e9c3a-7d2f-4e8a-a6b0-3c4d5e6f7a8b/3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
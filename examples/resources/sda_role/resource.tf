@@ -0,0 +1,35 @@
+resource "sda_role" "plant_operator" {
+  name        = "plant-operator"
+  description = "Read access to the devices of the plant"
+
+  policy {
+    name      = "read-devices"
+    actions   = ["assets:Read*", "assets:List*"]
+    resources = ["assets:resource_group/*"]
+  }
+
+  policy {
+    name        = "deploy"
+    actions     = ["assets:Deploy*"]
+    resources   = ["assets:device/*"]
+    description = "Deploy projects to devices"
+  }
+}
+
+# The same role in every plant tenant, from a single provider configuration.
+variable "plant_tenant_ids" {
+  type = set(string)
+}
+
+resource "sda_role" "plant_auditor" {
+  for_each = var.plant_tenant_ids
+
+  tenant_id = each.value
+  name      = "plant-auditor"
+
+  policy {
+    name      = "read-all"
+    actions   = ["assets:Read*"]
+    resources = ["*"]
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = sda_role_membership.example
+  identity = {
+    user_role_id = "8c2e7b1a-5d4f-4a3e-9b6c-1f0d2e3a4b5c"
+  }
+}
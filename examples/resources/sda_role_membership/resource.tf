@@ -0,0 +1,9 @@
+# Owns the full member list of the role. Users granted the role in the UI are
+# reported as drift and removed on the next apply.
+resource "sda_role_membership" "plant_operators" {
+  user_role_id = sda_role.plant_operator.user_role_id
+  user_ids = [
+    sda_user.alice.user_id,
+    sda_user.bob.user_id,
+  ]
+}
@@ -0,0 +1,2 @@
+# The IdP group name must be percent-encoded if it contains "/".
+terraform import sda_role_sso_mapping.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d/plant-operators
@@ -0,0 +1,6 @@
+# Members of the IdP group "plant-operators" receive the role. Other IdP
+# groups mapped to the same role are left untouched.
+resource "sda_role_sso_mapping" "plant_operators" {
+  user_role_id = sda_role.plant_operator.user_role_id
+  sso_group    = "plant-operators"
+}
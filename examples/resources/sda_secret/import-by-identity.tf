@@ -0,0 +1,6 @@
+import {
+  to = sda_secret.example
+  identity = {
+    secret_id = "3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d"
+  }
+}
@@ -0,0 +1,6 @@
+# Import by secret ID
+terraform import sda_secret.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Import by name, optionally qualified with the vault name
+terraform import sda_secret.example secret:plc-password
+terraform import sda_secret.example vault:line-1/secret:plc-password
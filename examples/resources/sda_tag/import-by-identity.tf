@@ -0,0 +1,6 @@
+import {
+  to = sda_tag.example
+  identity = {
+    name = "production"
+  }
+}
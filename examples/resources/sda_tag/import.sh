@@ -0,0 +1 @@
+terraform import sda_tag.example production
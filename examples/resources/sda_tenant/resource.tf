@@ -0,0 +1,24 @@
+# One tenant per customer site, owned by the user the provider signs in with.
+resource "sda_tenant" "site_a" {
+  company_name     = "ACME Site A"
+  owner_email      = var.sda_username
+  owner_first_name = "Platform"
+  owner_last_name  = "Team"
+  owner_password   = var.sda_password
+  country          = "DE"
+  import_demo_data = false
+}
+
+# Creating a tenant does not select it. A provider alias selects the new
+# tenant through tenant_id.
+provider "sda" {
+  alias     = "site_a"
+  username  = var.sda_username
+  password  = var.sda_password
+  tenant_id = sda_tenant.site_a.tenant_id
+}
+
+resource "sda_tenant_settings" "site_a" {
+  provider    = sda.site_a
+  enforce_mfa = true
+}
@@ -0,0 +1,6 @@
+import {
+  to = sda_tenant_settings.this
+  identity = {
+    tenant_id = "5b1e9c3a-7d2f-4e8a-a6b0-3c4d5e6f7a8b"
+  }
+}
@@ -0,0 +1,5 @@
+# Settings that are not configured keep their current value.
+resource "sda_tenant_settings" "this" {
+  enforce_mfa                   = true
+  max_user_role_expiration_days = 90
+}
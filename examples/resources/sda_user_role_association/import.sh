@@ -0,0 +1,2 @@
+# Import format: [tenant:<tenant_id>/]user_id/user_role_id
+terraform import sda_user_role_association.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d/8c2e7b1a-5d4f-4a3e-9b6c-1f0d2e3a4b5c
@@ -0,0 +1,13 @@
+# Permanent assignment.
+resource "sda_user_role_association" "operator" {
+  user_id      = sda_user.operator.user_id
+  user_role_id = sda_role.plant_operator.user_role_id
+}
+
+# Break-glass access that expires 72 hours after apply. Once expired, the next
+# apply grants it again for another 72 hours.
+resource "sda_user_role_association" "break_glass" {
+  user_id      = sda_user.on_call.user_id
+  user_role_id = sda_role.admin.user_role_id
+  duration     = "72h"
+}
@@ -0,0 +1,6 @@
+# Import by vault ID
+terraform import sda_vault.example 3f6a2d4e-9b1c-4e5f-8a7d-2c1b0e9f4a6d
+
+# Import by name, optionally qualified with the resource group name
+terraform import sda_vault.example vault:line-1-secrets
+terraform import sda_vault.example group:line-1/vault:line-1-secrets
@@ -2,41 +2,95 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// Sign up - Create new tenant, return tenant token upon successful creation
-func (c *Client) SignUp(auth AuthStruct) (*AuthResponse, error) {
-	if auth.Username == "" || auth.Password == "" {
-		return nil, fmt.Errorf("define username and password")
+// SignUp - Create a new tenant owned by the user in tenant. The tenant is not
+// selected: use SelectTenant to work in it.
+func (c *Client) SignUp(tenant CreateTenantRequest) (*TenantResponse, error) {
+	if tenant.Email == "" || tenant.Password == "" {
+		return nil, fmt.Errorf("define email and password")
 	}
-	rb, err := json.Marshal(auth)
+	rb, err := json.Marshal(tenant)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/ident/v1/tenant", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/ident/v1/tenant", c.HostURL), bytes.NewReader(rb))
 	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/json")
 
 	body, err := c.DoRequest(req, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	ar := AuthResponse{}
-	err = json.Unmarshal(body, &ar)
+	tr := TenantResponse{}
+	err = json.Unmarshal(body, &tr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ar, nil
+	return &tr, nil
+}
+
+// ConfirmUser - Confirm the sign up of a user with the code sent to them
+func (c *Client) ConfirmUser(confirm ConfirmUserRequest) error {
+	if confirm.Username == "" || confirm.ConfirmationCode == "" {
+		return fmt.Errorf("define username and confirmation code")
+	}
+	rb, err := json.Marshal(confirm)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/ident/v1/user/confirm", c.HostURL), bytes.NewReader(rb))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = c.DoRequest(req, nil)
+	return err
+}
+
+// ForgotPassword - Send a password reset code to a user
+func (c *Client) ForgotPassword(forgot ForgotPasswordRequest) (*ForgotPasswordResponse, error) {
+	if forgot.Username == "" {
+		return nil, fmt.Errorf("define username")
+	}
+	rb, err := json.Marshal(forgot)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/ident/v1/user/forgot_password", c.HostURL), bytes.NewReader(rb))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	body, err := c.DoRequest(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fr := ForgotPasswordResponse{}
+	err = json.Unmarshal(body, &fr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fr, nil
 }
 
 // SignIn - Get a new token for user
@@ -49,7 +103,7 @@ func (c *Client) SignIn() (*AuthResponse, error) {
 		return nil, err
 	}
 
-	log.Print("Sending Request to ", c.HostURL)
+	tflog.Debug(c.logContext(), "Signing in", map[string]any{"host": c.HostURL, "username": c.Auth.Username})
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/ident/v1/user/login", c.HostURL), bytes.NewReader(rb))
 	if err != nil {
@@ -75,7 +129,36 @@ func (c *Client) SignIn() (*AuthResponse, error) {
 		return nil, err
 	}
 
-	log.Print(ar.IdToken)
+	return &ar, nil
+}
+
+// Refresh - Get a new token for the selected tenant with the refresh token
+func (c *Client) Refresh() (*AuthResponse, error) {
+	if c.RefreshToken == "" {
+		return nil, fmt.Errorf("provide a refresh token")
+	}
+	rb, err := json.Marshal(map[string]string{"refresh_token": c.RefreshToken})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/ident/v1/user/refresh", c.HostURL), bytes.NewReader(rb))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	body, err := c.DoRequest(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := AuthResponse{}
+	err = json.Unmarshal(body, &ar)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ar, nil
 }
 
@@ -108,9 +191,9 @@ func (c *Client) GetUserTokenSignIn(auth AuthStruct) (*AuthResponse, error) {
 	return &ar, nil
 }
 
-// SignOut - Revoke the token for a user
-func (c *Client) SignOut(authToken *string) error {
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/ident/v1/user/logout", c.HostURL), strings.NewReader(string("")))
+// SignOut - Revoke the token for a user, giving up when ctx is done
+func (c *Client) SignOut(ctx context.Context, authToken *string) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/ident/v1/user/logout", c.HostURL), strings.NewReader(string("")))
 	if err != nil {
 		return err
 	}
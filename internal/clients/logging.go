@@ -0,0 +1,93 @@
+package clients
+
+import (
+	"context"
+	"regexp"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sensitiveFields are log field keys whose values are masked.
+var sensitiveFields = []string{
+	"Authorization", "authorization", "password", "secret_value",
+	"token", "id_token", "access_token", "refresh_token",
+}
+
+// jwtPattern matches JSON web tokens, such as ID tokens, anywhere in a log
+// message or field value.
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// MaskSecrets returns ctx with tflog masking of credentials: the values of
+// sensitive fields, and tokens in messages and field values.
+func MaskSecrets(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveFields...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, jwtPattern)
+	ctx = tflog.MaskMessageRegexes(ctx, jwtPattern)
+	return ctx
+}
+
+// logContext returns the context the client logs with.
+func (c *Client) logContext() context.Context {
+	if c.logCtx == nil {
+		return context.Background()
+	}
+	return c.logCtx
+}
+
+// session is a token the provider signed in, to be signed out on shutdown.
+type session struct {
+	client *Client
+	token  string
+}
+
+// sessions are the sessions signed in by this process.
+var sessions struct {
+	mu   sync.Mutex
+	list []session
+}
+
+// ownsSessions reports whether the sessions of c are created by the provider,
+// by signing in or exchanging an OIDC token. Sessions of pre-issued tokens
+// and refresh tokens are left alone, signing them out would revoke the
+// credentials themselves.
+func (c *Client) ownsSessions() bool {
+	return c.Source != nil || (c.Auth.Username != "" && c.Auth.Password != "")
+}
+
+// trackSession records the session of token for SignOutAll.
+func (c *Client) trackSession(token string) {
+	if token == "" || !c.ownsSessions() {
+		return
+	}
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	sessions.list = append(sessions.list, session{client: c, token: token})
+}
+
+// SignOutAll signs out the sessions created by the clients of this process,
+// so that no live tokens remain once the provider stops. The sessions are
+// signed out concurrently and abandoned when ctx is done. Signing out is
+// best-effort: failures are logged and otherwise ignored, and sessions that
+// are not signed out expire with their tokens.
+func SignOutAll(ctx context.Context) {
+	sessions.mu.Lock()
+	list := sessions.list
+	sessions.list = nil
+	sessions.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, s := range list {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token := s.token
+			if err := s.client.SignOut(ctx, &token); err != nil {
+				tflog.Warn(s.client.logContext(), "Unable to sign out SDA session", map[string]any{"error": err.Error()})
+				return
+			}
+			tflog.Debug(s.client.logContext(), "Signed out SDA session")
+		}()
+	}
+	wg.Wait()
+}
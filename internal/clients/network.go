@@ -0,0 +1,67 @@
+package clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransportConfig - How the client reaches the API and the upload URLs.
+type TransportConfig struct {
+	// ProxyURL is the proxy of all requests, with the proxy credentials as
+	// user info if the proxy authenticates. Empty to use the proxy of the
+	// HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// CACertPEM holds PEM encoded certificates trusted in addition to the
+	// system roots, such as the CA of a TLS inspecting proxy.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM are the PEM encoded certificate and key
+	// presented for mutual TLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// InsecureSkipVerify disables the verification of server certificates.
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns an HTTP transport for cfg, based on the defaults of
+// http.DefaultTransport.
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the proxy URL: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, errors.New("the proxy URL must have a scheme and a host, such as http://proxy.example.com:3128")
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(cfg.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+			return nil, errors.New("the CA bundle holds no PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(cfg.ClientCertPEM) > 0 || len(cfg.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	tlsConfig.InsecureSkipVerify = cfg.InsecureSkipVerify
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
@@ -0,0 +1,116 @@
+package clients
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// selfSigned returns a PEM encoded self-signed client certificate and its key.
+func selfSigned(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestNewTransport(t *testing.T) {
+	clientCert, clientKey := selfSigned(t)
+	clientCA := x509.NewCertPool()
+	clientCA.AppendCertsFromPEM(clientCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Client-Certificates", fmt.Sprint(len(r.TLS.PeerCertificates)))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.VerifyClientCertIfGiven, ClientCAs: clientCA}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// get returns the number of client certificates the server received.
+	get := func(cfg TransportConfig) (string, error) {
+		transport, err := NewTransport(cfg)
+		if err != nil {
+			return "", err
+		}
+		res, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err != nil {
+			return "", err
+		}
+		res.Body.Close()
+		return res.Header.Get("X-Client-Certificates"), nil
+	}
+
+	if _, err := get(TransportConfig{}); err == nil {
+		t.Error("expected the self-signed server certificate to be rejected")
+	}
+	if _, err := get(TransportConfig{CACertPEM: serverCA}); err != nil {
+		t.Errorf("with the CA bundle: %v", err)
+	}
+	if _, err := get(TransportConfig{InsecureSkipVerify: true}); err != nil {
+		t.Errorf("with insecure_skip_verify: %v", err)
+	}
+	if n, err := get(TransportConfig{CACertPEM: serverCA, ClientCertPEM: clientCert, ClientKeyPEM: clientKey}); err != nil || n != "1" {
+		t.Errorf("with a client certificate: %s certificates received, %v", n, err)
+	}
+
+	if _, err := NewTransport(TransportConfig{CACertPEM: []byte("not a certificate")}); err == nil {
+		t.Error("expected an error for a CA bundle without certificates")
+	}
+	if _, err := NewTransport(TransportConfig{ClientCertPEM: clientCert}); err == nil {
+		t.Error("expected an error for a client certificate without key")
+	}
+	if _, err := NewTransport(TransportConfig{ProxyURL: "proxy.example.com:3128"}); err == nil {
+		t.Error("expected an error for a proxy URL without scheme")
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	var proxied, proxyAuth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		proxyAuth = r.Header.Get("Proxy-Authorization")
+		w.Write([]byte(`{"id_token": "t"}`))
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(TransportConfig{ProxyURL: "http://plant:secret@" + proxy.Listener.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRestClient(t.Context(), "http://sda.invalid", Credentials{Username: "u", Password: "p"}, "", transport, Limits{}); err != nil {
+		t.Fatal(err)
+	}
+	if proxied != "http://sda.invalid/ident/v1/user/login" {
+		t.Errorf("the proxy received %q", proxied)
+	}
+	if proxyAuth == "" {
+		t.Error("the proxy credentials were not sent")
+	}
+}
@@ -1,11 +1,14 @@
 package clients
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Client -
@@ -17,6 +20,20 @@ type Client struct {
 	RefreshToken string
 	ExpiresIn    int64
 	Auth         AuthStruct
+	// TenantID is the tenant the tokens belong to, empty until known.
+	TenantID string
+	// Source supplies tokens instead of signing in, nil to sign in with Auth.
+	Source TokenSource
+
+	tenants *tenantTokens
+	// uploadClient sends requests to presigned URLs, without a timeout as
+	// uploads of large files take long.
+	uploadClient *http.Client
+	// logCtx carries the tflog logger of the provider, with secrets masked.
+	logCtx context.Context
+	// throttle holds API requests back, shared with the clients scoped from
+	// this one. Nil does not limit.
+	throttle *throttle
 }
 
 // AuthStruct -
@@ -35,54 +52,129 @@ type AuthResponse struct {
 	Token        string `json:"token"`
 }
 
-// NewClient -
-func NewRestClient(host, username, password *string, tenantID string) (*Client, error) {
-	log.Print("Creating new REST Client")
+// errCannotRenew is returned when a client authenticated with a pre-issued
+// token needs a new token.
+var errCannotRenew = errors.New("obtaining a new token requires username and password or a refresh token")
+
+// ErrNotConfigured is reported instead of calling the API when the provider
+// configuration depends on values that are unknown during plan and Terraform
+// cannot defer the provider, so that no client was created.
+var ErrNotConfigured = errors.New("the SDA provider configuration depends on values that are only known after apply, such as the tenant_id of an sda_tenant created in the same run. " +
+	"Apply the resources the provider configuration depends on first, for example with -target, or use a Terraform version that supports deferred changes")
+
+// Credentials - How a client authenticates. Exactly one of the username and
+// password, Token or RefreshToken is expected.
+type Credentials struct {
+	Username string
+	Password string
+	// Token is a pre-issued ID token, used as is.
+	Token string
+	// RefreshToken obtains ID tokens without a password.
+	RefreshToken string
+	// Source supplies ID tokens, for example from an OIDC token exchange.
+	Source TokenSource
+}
+
+// NewClient - base sends the requests of the client, nil for the default
+// transport. Limits apply to the API requests of the client and of the
+// clients scoped from it.
+func NewRestClient(ctx context.Context, host string, creds Credentials, tenantID string, base http.RoundTripper, limits Limits) (*Client, error) {
 	c := Client{
-		HTTPClient: &http.Client{Timeout: 30 * time.Second},
-		HostURL:    *host,
+		HTTPClient:   &http.Client{Timeout: 30 * time.Second},
+		HostURL:      host,
+		Auth:         AuthStruct{Username: creds.Username, Password: creds.Password},
+		RefreshToken: creds.RefreshToken,
+		Source:       creds.Source,
+		tenants:      &tenantTokens{tokens: map[string]*tenantToken{}},
+		logCtx:       MaskSecrets(ctx),
 	}
-
-	// If username, password or host url are not provided, return empty client
-	if username == nil || password == nil || host == nil {
+	c.HTTPClient.Transport = newLoggingTransport(c.logCtx, newTracingTransport(base))
+	c.throttle = newThrottle(c.logCtx, limits)
+	// Uploads go to presigned storage URLs, not to the API gateway, so the
+	// limits do not apply to them.
+	c.uploadClient = &http.Client{Transport: c.HTTPClient.Transport}
+
+	// A pre-issued token cannot be renewed for another tenant, so it must
+	// belong to the selected one.
+	if creds.Token != "" {
+		c.IdToken = creds.Token
+		if tenantID != "" {
+			current, err := c.currentTenantID(c.IdToken)
+			if err != nil {
+				return nil, fmt.Errorf("reading the tenant of the token: %w", err)
+			}
+			if current != tenantID {
+				return nil, fmt.Errorf("the token belongs to tenant %s, not to tenant %s; issue a token for tenant %s or remove tenant_id", current, tenantID, tenantID)
+			}
+			c.TenantID = tenantID
+		}
 		return &c, nil
 	}
 
-	c.Auth = AuthStruct{
-		Username: *username,
-		Password: *password,
-	}
+	tflog.Debug(c.logContext(), "Creating SDA REST client")
 
-	ar, err := c.SignIn()
+	ar, err := c.renew()
 	if err != nil {
 		return nil, err
 	}
 
 	// Check if tenantID is provided, if so select tenant for the session and get new token for the tenant
 	if tenantID != "" {
-		log.Print("Switching to tenant: ", tenantID)
+		tflog.Debug(c.logContext(), "Switching to tenant", map[string]any{"tenant_id": tenantID})
 		err = c.SelectTenant(tenantID, &ar.IdToken)
+		// The session of the default tenant ends here, also on failure.
+		c.trackSession(ar.IdToken)
 		if err != nil {
 			return nil, err
 		}
 
-		ar, err = c.SignIn()
+		ar, err = c.renew()
 		if err != nil {
 			return nil, err
 		}
+		c.TenantID = tenantID
+	}
+
+	c.setTokens(ar)
+	c.trackSession(c.IdToken)
+	c.tenants.root = &tenantToken{auth: ar, expires: tokenExpiry(ar)}
+
+	return &c, nil
+}
+
+// renew obtains a new token for the selected tenant from the token source, by
+// signing in with the password or else with the refresh token.
+func (c *Client) renew() (*AuthResponse, error) {
+	switch {
+	case c.Source != nil:
+		return c.Source.Token(c)
+	case c.Auth.Username != "" && c.Auth.Password != "":
+		return c.SignIn()
+	case c.RefreshToken != "":
+		return c.Refresh()
+	default:
+		return nil, errCannotRenew
 	}
+}
 
+// canRenew reports whether renew can obtain a new token.
+func (c *Client) canRenew() bool {
+	return c.Source != nil || (c.Auth.Username != "" && c.Auth.Password != "") || c.RefreshToken != ""
+}
+
+// setTokens stores the tokens of ar. A response without a refresh token
+// keeps the current one.
+func (c *Client) setTokens(ar *AuthResponse) {
 	c.IdToken = ar.IdToken
 	c.AccessToken = ar.AccessToken
-	c.RefreshToken = ar.RefreshToken
+	if ar.RefreshToken != "" {
+		c.RefreshToken = ar.RefreshToken
+	}
 	c.ExpiresIn = ar.ExpiresIn
-
-	return &c, nil
 }
 
 func (c *Client) DoRequest(req *http.Request, authToken *string) ([]byte, error) {
 	token := c.IdToken
-	log.Print(token)
 
 	if authToken != nil {
 		token = *authToken
@@ -90,7 +182,7 @@ func (c *Client) DoRequest(req *http.Request, authToken *string) ([]byte, error)
 
 	req.Header.Set("Authorization", token)
 
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -102,8 +194,43 @@ func (c *Client) DoRequest(req *http.Request, authToken *string) ([]byte, error)
 	}
 
 	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, statusError(res, body)
 	}
 
 	return body, err
 }
+
+// do sends req with HTTPClient once the limits of the client let it through.
+// The request holds its slot until the response body is closed.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	release, err := c.throttle.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// Upload sends req to a presigned URL, such as the upload URL of a file part,
+// through the same logging and tracing as API calls.
+func (c *Client) Upload(req *http.Request) (*http.Response, error) {
+	if c.uploadClient == nil {
+		return http.DefaultClient.Do(req)
+	}
+	return c.uploadClient.Do(req)
+}
+
+// statusError returns the error of a response with an unexpected status. It
+// includes the API gateway request ID, so that SDA support can trace the call.
+func statusError(res *http.Response, body []byte) error {
+	if id := requestID(res.Header); id != "" {
+		return fmt.Errorf("status: %d, request_id: %s, body: %s", res.StatusCode, id, body)
+	}
+	return fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+}
@@ -1,9 +1,16 @@
 package clients
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // SelectTenant - Select tenant for the current session, return 204 if successful
@@ -15,7 +22,7 @@ func (c *Client) SelectTenant(tenantId string, authToken *string) error {
 
 	req.Header.Set("Authorization", *authToken)
 
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -27,8 +34,209 @@ func (c *Client) SelectTenant(tenantId string, authToken *string) error {
 	}
 
 	if res.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return statusError(res, body)
 	}
 
 	return nil
 }
+
+// CreateTenantRequest - Body of a tenant sign up. The owner is created as a
+// new user unless the email already belongs to one.
+type CreateTenantRequest struct {
+	FirstName                 string  `json:"first_name"`
+	LastName                  string  `json:"last_name"`
+	Email                     string  `json:"email"`
+	CompanyName               string  `json:"company_name"`
+	Password                  string  `json:"password"`
+	PasswordConfirmation      string  `json:"password_confirmation"`
+	PhoneNumber               *string `json:"phone_number,omitempty"`
+	Locale                    *string `json:"locale,omitempty"`
+	Country                   *string `json:"country,omitempty"`
+	Industry                  *string `json:"industry,omitempty"`
+	EnforceMFA                *bool   `json:"enforce_mfa,omitempty"`
+	ImportDemoData            *bool   `json:"import_demo_data,omitempty"`
+	MaxUserRoleExpirationDays *int64  `json:"max_user_role_expiration_days,omitempty"`
+}
+
+// TenantResponse -
+type TenantResponse struct {
+	ObjectVersion             int64   `json:"object_version"`
+	CreationTimestamp         *string `json:"creation_timestamp"`
+	TenantID                  string  `json:"tenant_id"`
+	OwnerID                   string  `json:"owner_id"`
+	CompanyName               string  `json:"company_name"`
+	Country                   *string `json:"country"`
+	Industry                  *string `json:"industry"`
+	IsActive                  bool    `json:"is_active"`
+	EnforceMFA                *bool   `json:"enforce_mfa"`
+	ImportDemoData            *bool   `json:"import_demo_data"`
+	MaxUserRoleExpirationDays *int64  `json:"max_user_role_expiration_days"`
+	ExistingUser              *bool   `json:"existing_user"`
+}
+
+// tenantTokens caches the tokens of the tenants a client switched to, shared
+// by the client and the clients ForTenant derives from it. mu also serializes
+// tenant switches, as the selected tenant is per user.
+type tenantTokens struct {
+	mu sync.Mutex
+	// defaultTenant is the tenant of a client created without tenant ID,
+	// resolved on first use.
+	defaultTenant string
+	// root is the token of the default tenant that tenant switches are
+	// authorized with, nil for the token of the client with unknown expiry.
+	root   *tenantToken
+	tokens map[string]*tenantToken
+}
+
+// tenantToken is the token of a tenant and the time it expires, zero when
+// unknown.
+type tenantToken struct {
+	auth    *AuthResponse
+	expires time.Time
+}
+
+// tokenExpiryMargin is how long before they expire cached tokens are renewed,
+// so that they do not expire during a request.
+const tokenExpiryMargin = time.Minute
+
+// expired reports whether t must be renewed before it is used.
+func (t *tenantToken) expired() bool {
+	return !t.expires.IsZero() && time.Until(t.expires) < tokenExpiryMargin
+}
+
+// ForTenant returns a client whose requests run in the tenant tenantID. The
+// token of each tenant is obtained by selecting the tenant and renewing the
+// token, and then reused until it expires. Afterwards the default tenant is
+// selected again so that later sign ins of the user are not affected. An empty
+// tenantID or the tenant of c return c itself. c is not modified, so that
+// resources can switch tenants concurrently.
+func (c *Client) ForTenant(tenantID string) (*Client, error) {
+	if tenantID == "" || tenantID == c.TenantID {
+		return c, nil
+	}
+	if c.tenants == nil {
+		return nil, fmt.Errorf("switching to tenant %s: the client was not created by NewRestClient", tenantID)
+	}
+
+	cache := c.tenants
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	rootToken, err := c.rootToken()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultTenant := c.TenantID
+	if defaultTenant == "" {
+		if cache.defaultTenant == "" {
+			current, err := c.currentTenantID(rootToken)
+			if err != nil {
+				return nil, fmt.Errorf("reading the default tenant: %w", err)
+			}
+			cache.defaultTenant = current
+		}
+		defaultTenant = cache.defaultTenant
+		if tenantID == defaultTenant {
+			return c, nil
+		}
+	}
+
+	token, ok := cache.tokens[tenantID]
+	if !ok || token.expired() {
+		if token, err = c.tenantToken(tenantID, defaultTenant, rootToken); err != nil {
+			return nil, err
+		}
+		cache.tokens[tenantID] = token
+	}
+
+	scoped := *c
+	scoped.TenantID = tenantID
+	scoped.setTokens(token.auth)
+	return &scoped, nil
+}
+
+// rootToken returns the token tenant switches are authorized with, renewed
+// through renew when it expires. renew obtains a token of the default tenant,
+// as every switch selects it again. The caller must hold c.tenants.mu.
+func (c *Client) rootToken() (string, error) {
+	cache := c.tenants
+	if cache.root == nil {
+		cache.root = &tenantToken{auth: &AuthResponse{IdToken: c.IdToken}}
+	}
+	if cache.root.expired() && c.canRenew() {
+		tflog.Debug(c.logContext(), "Renewing token of the default tenant")
+		ar, err := c.renew()
+		if err != nil {
+			return "", fmt.Errorf("renewing the token of the default tenant: %w", err)
+		}
+		c.trackSession(ar.IdToken)
+		cache.root = &tenantToken{auth: ar, expires: tokenExpiry(ar)}
+	}
+	return cache.root.auth.IdToken, nil
+}
+
+// tenantToken obtains a token of tenantID through renew, authorizing the
+// tenant switches with rootToken, and selects defaultTenant again afterwards.
+func (c *Client) tenantToken(tenantID, defaultTenant, rootToken string) (*tenantToken, error) {
+	if !c.canRenew() {
+		return nil, fmt.Errorf("switching to tenant %s: %w", tenantID, errCannotRenew)
+	}
+	tflog.Debug(c.logContext(), "Obtaining token for tenant", map[string]any{"tenant_id": tenantID})
+	if err := c.SelectTenant(tenantID, &rootToken); err != nil {
+		return nil, fmt.Errorf("selecting tenant %s: %w", tenantID, err)
+	}
+
+	ar, err := c.renew()
+	if err != nil {
+		return nil, fmt.Errorf("signing in to tenant %s: %w", tenantID, err)
+	}
+
+	if err := c.SelectTenant(defaultTenant, &rootToken); err != nil {
+		return nil, fmt.Errorf("selecting default tenant %s again: %w", defaultTenant, err)
+	}
+	c.trackSession(ar.IdToken)
+	return &tenantToken{auth: ar, expires: tokenExpiry(ar)}, nil
+}
+
+// tokenExpiry returns when the token of ar expires, from its lifetime or else
+// from the exp claim of the ID token, and zero when neither is known.
+func tokenExpiry(ar *AuthResponse) time.Time {
+	if ar.ExpiresIn > 0 {
+		return time.Now().Add(time.Duration(ar.ExpiresIn) * time.Second)
+	}
+	parts := strings.Split(ar.IdToken, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// currentTenantID returns the tenant authToken belongs to.
+func (c *Client) currentTenantID(authToken string) (string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/ident/v1/tenant", c.HostURL), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := c.DoRequest(req, &authToken)
+	if err != nil {
+		return "", err
+	}
+
+	tr := TenantResponse{}
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return "", err
+	}
+	return tr.TenantID, nil
+}
@@ -0,0 +1,174 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForTenant(t *testing.T) {
+	var mu sync.Mutex
+	selected := "t1"
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/ident/v1/tenant":
+			w.Write([]byte(`{"tenant_id": "t1", "owner_id": "u1", "company_name": "HQ"}`))
+		case strings.HasPrefix(r.URL.Path, "/ident/v1/tenant/select/"):
+			selected = strings.TrimPrefix(r.URL.Path, "/ident/v1/tenant/select/")
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/ident/v1/user/login":
+			w.Write([]byte(`{"id_token": "token-` + selected + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{HostURL: server.URL, HTTPClient: server.Client(), IdToken: "token-t1", Auth: AuthStruct{Username: "u", Password: "p"},
+		tenants: &tenantTokens{tokens: map[string]*tenantToken{}}}
+
+	// Resources switch tenants concurrently.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scoped, err := c.ForTenant("t2")
+			if err != nil {
+				t.Errorf("ForTenant: %s", err)
+				return
+			}
+			if scoped.IdToken != "token-t2" || scoped.TenantID != "t2" {
+				t.Errorf("scoped client = %+v", scoped)
+			}
+		}()
+	}
+	wg.Wait()
+	if c.TenantID != "" {
+		t.Fatalf("ForTenant changed the tenant of the client to %s", c.TenantID)
+	}
+
+	if same, _ := c.ForTenant("t1"); same != c {
+		t.Fatalf("ForTenant of the default tenant returned a new client")
+	}
+	if c.IdToken != "token-t1" || selected != "t1" {
+		t.Fatalf("default tenant changed: token %s, selected %s", c.IdToken, selected)
+	}
+
+	// The token of t2 is obtained once.
+	want := []string{
+		"GET /ident/v1/tenant",
+		"POST /ident/v1/tenant/select/t2",
+		"POST /ident/v1/user/login",
+		"POST /ident/v1/tenant/select/t1",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestForTenantRenewsExpiredTokens(t *testing.T) {
+	// The ID tokens expire within the renewal margin, so each use renews them.
+	exp := time.Now().Add(tokenExpiryMargin / 2).Unix()
+	idToken := "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp": %d}`, exp))) + ".c2ln"
+	signIns := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/ident/v1/tenant/select/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/ident/v1/user/login":
+			signIns++
+			w.Write([]byte(`{"id_token": "` + idToken + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{HostURL: server.URL, HTTPClient: server.Client(), IdToken: "token-t1", TenantID: "t1", Auth: AuthStruct{Username: "u", Password: "p"},
+		tenants: &tenantTokens{tokens: map[string]*tenantToken{}}}
+	for range 2 {
+		if _, err := c.ForTenant("t2"); err != nil {
+			t.Fatalf("ForTenant: %s", err)
+		}
+	}
+	if signIns != 2 {
+		t.Fatalf("signed in %d times, want the expired token to be renewed", signIns)
+	}
+}
+
+func TestForTenantRenewsDefaultTenantToken(t *testing.T) {
+	selected := "t1"
+	var switchTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/ident/v1/tenant/select/"):
+			switchTokens = append(switchTokens, r.Header.Get("Authorization"))
+			selected = strings.TrimPrefix(r.URL.Path, "/ident/v1/tenant/select/")
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/ident/v1/user/login":
+			w.Write([]byte(`{"id_token": "token-` + selected + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	// The token of the default tenant has expired since the client was created.
+	c := &Client{HostURL: server.URL, HTTPClient: server.Client(), IdToken: "expired", TenantID: "t1", Auth: AuthStruct{Username: "u", Password: "p"},
+		tenants: &tenantTokens{tokens: map[string]*tenantToken{}, root: &tenantToken{auth: &AuthResponse{IdToken: "expired"}, expires: time.Now()}}}
+
+	scoped, err := c.ForTenant("t2")
+	if err != nil {
+		t.Fatalf("ForTenant: %s", err)
+	}
+	if scoped.IdToken != "token-t2" {
+		t.Fatalf("scoped token = %s, want token-t2", scoped.IdToken)
+	}
+	if want := []string{"token-t1", "token-t1"}; !reflect.DeepEqual(switchTokens, want) {
+		t.Fatalf("tenant switches authorized with %v, want the renewed token %v", switchTokens, want)
+	}
+	if c.IdToken != "expired" {
+		t.Fatalf("ForTenant changed the token of the client to %s", c.IdToken)
+	}
+}
+
+func TestNewRestClientWithTokens(t *testing.T) {
+	var refreshed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ident/v1/tenant":
+			w.Write([]byte(`{"tenant_id": "t1"}`))
+		case strings.HasPrefix(r.URL.Path, "/ident/v1/tenant/select/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/ident/v1/user/refresh":
+			refreshed = append(refreshed, r.Header.Get("Authorization"))
+			w.Write([]byte(`{"id_token": "refreshed", "access_token": "a"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewRestClient(context.Background(), server.URL, Credentials{Token: "pre-issued"}, "t1", nil, Limits{})
+	if err != nil || c.IdToken != "pre-issued" || c.TenantID != "t1" {
+		t.Fatalf("NewRestClient with token = %+v, %v", c, err)
+	}
+	if _, err := NewRestClient(context.Background(), server.URL, Credentials{Token: "pre-issued"}, "t2", nil, Limits{}); err == nil {
+		t.Fatalf("expected an error for a token of another tenant")
+	}
+	if _, err := c.ForTenant("t2"); err == nil {
+		t.Fatalf("expected an error switching tenants with a pre-issued token")
+	}
+
+	c, err = NewRestClient(context.Background(), server.URL, Credentials{RefreshToken: "r"}, "t2", nil, Limits{})
+	if err != nil || c.IdToken != "refreshed" || c.RefreshToken != "r" {
+		t.Fatalf("NewRestClient with refresh token = %+v, %v", c, err)
+	}
+	if len(refreshed) != 2 {
+		t.Fatalf("refreshed %d times, want 2", len(refreshed))
+	}
+}
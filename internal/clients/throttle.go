@@ -0,0 +1,139 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// throttleLogInterval is the minimum interval between two log entries about
+// client-side throttling.
+const throttleLogInterval = 10 * time.Second
+
+// Limits - Client-side limits of the requests of a client and the clients
+// scoped from it. Zero values do not limit.
+type Limits struct {
+	// RequestsPerSecond is the sustained request rate, allowing bursts of
+	// up to one second of requests.
+	RequestsPerSecond float64
+	// MaxConcurrentRequests is the number of requests in flight at once.
+	MaxConcurrentRequests int
+}
+
+// throttle holds requests back until the rate limiter and the semaphore of
+// in-flight requests let them through, so that large applies stay within the
+// throttling limits of the API gateway. Requests acquire it before they are
+// sent, so that the time they wait does not count against the timeout of the
+// HTTP client.
+type throttle struct {
+	ctx     context.Context
+	limits  Limits
+	limiter *rate.Limiter
+	slots   chan struct{}
+
+	mu        sync.Mutex
+	throttled int
+	waited    time.Duration
+	lastLog   time.Time
+}
+
+// newThrottle returns nil when limits does not limit, and otherwise a
+// throttle logging with the logger of ctx.
+func newThrottle(ctx context.Context, limits Limits) *throttle {
+	if limits.RequestsPerSecond <= 0 && limits.MaxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	t := &throttle{ctx: ctx, limits: limits}
+	if limits.RequestsPerSecond > 0 {
+		burst := int(math.Max(1, math.Ceil(limits.RequestsPerSecond)))
+		t.limiter = rate.NewLimiter(rate.Limit(limits.RequestsPerSecond), burst)
+	}
+	if limits.MaxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, limits.MaxConcurrentRequests)
+	}
+	return t
+}
+
+// acquire waits until req may be sent, and returns the function releasing
+// its slot once the response is read. A nil throttle does not wait.
+func (t *throttle) acquire(req *http.Request) (release func(), err error) {
+	release = func() {}
+	if t == nil {
+		return release, nil
+	}
+	ctx := req.Context()
+	start := time.Now()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-t.slots }) }
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	if wait := time.Since(start); wait >= time.Millisecond {
+		trace.SpanFromContext(ctx).AddEvent("throttled", trace.WithAttributes(attribute.Int64("wait_ms", wait.Milliseconds())))
+		t.record(req, wait)
+	}
+	return release, nil
+}
+
+// releasingBody releases the slot of a request when the body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer.
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// record logs a request held back for wait. Each request is logged at DEBUG
+// level, and a summary at most every throttleLogInterval at INFO level.
+func (t *throttle) record(req *http.Request, wait time.Duration) {
+	tflog.Debug(t.ctx, "Throttled SDA API request client-side", map[string]any{
+		"method":  req.Method,
+		"url":     redactURL(req.URL),
+		"wait_ms": wait.Milliseconds(),
+	})
+
+	t.mu.Lock()
+	t.throttled++
+	t.waited += wait
+	if time.Since(t.lastLog) < throttleLogInterval {
+		t.mu.Unlock()
+		return
+	}
+	throttled, waited := t.throttled, t.waited
+	t.throttled, t.waited, t.lastLog = 0, 0, time.Now()
+	t.mu.Unlock()
+
+	tflog.Info(t.ctx, "SDA API requests are being throttled client-side", map[string]any{
+		"throttled_requests":      throttled,
+		"total_wait_ms":           waited.Milliseconds(),
+		"requests_per_second":     t.limits.RequestsPerSecond,
+		"max_concurrent_requests": t.limits.MaxConcurrentRequests,
+	})
+}
@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestThrottleTransport(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{}`))
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+
+	// run sends n requests at once with a client timing out after timeout,
+	// and returns how long they took.
+	run := func(limits Limits, n int, timeout time.Duration) time.Duration {
+		c := &Client{HostURL: server.URL, HTTPClient: &http.Client{Timeout: timeout}, throttle: newThrottle(ctx, limits)}
+		start := time.Now()
+		var wg sync.WaitGroup
+		for range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				req, _ := http.NewRequest(http.MethodGet, server.URL+"/assets/v1/device", nil)
+				if _, err := c.DoRequest(req, nil); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+		return time.Since(start)
+	}
+
+	run(Limits{MaxConcurrentRequests: 2}, 10, 0)
+	if maxInFlight != 2 {
+		t.Errorf("%d requests in flight at once, want 2", maxInFlight)
+	}
+	if !strings.Contains(out.String(), "SDA API requests are being throttled client-side") {
+		t.Errorf("throttling is not logged: %s", out.String())
+	}
+
+	// A burst of 50 requests passes at once, the other 25 at 50 per second.
+	if elapsed := run(Limits{RequestsPerSecond: 50}, 75, 0); elapsed < 400*time.Millisecond {
+		t.Errorf("75 requests at 50 per second took %s", elapsed)
+	}
+
+	// Queued requests wait longer than the timeout, which only starts once
+	// they are sent.
+	if elapsed := run(Limits{MaxConcurrentRequests: 1}, 10, 100*time.Millisecond); elapsed < 200*time.Millisecond {
+		t.Errorf("10 requests one at a time took %s", elapsed)
+	}
+
+	if newThrottle(ctx, Limits{}) != nil {
+		t.Errorf("without limits requests are throttled")
+	}
+}
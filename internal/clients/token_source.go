@@ -0,0 +1,100 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TokenSource - Supplies new tokens for a client, for the tenant selected for
+// its user. A client with a TokenSource uses it instead of signing in.
+type TokenSource interface {
+	Token(c *Client) (*AuthResponse, error)
+}
+
+// DefaultOIDCExchangeEndpoint is the path of the Ident Service endpoint that
+// exchanges an OIDC token for an SDA session.
+const DefaultOIDCExchangeEndpoint = "/ident/v1/user/token_exchange"
+
+// jwtTokenType identifies the subject token of an exchange as a JWT (RFC 8693).
+const jwtTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+// OIDCTokenSource - Exchanges an OIDC token issued to a CI pipeline, read from
+// an environment variable, for an SDA session.
+type OIDCTokenSource struct {
+	// TokenEnv names the environment variable holding the OIDC token.
+	TokenEnv string
+	// Audience is passed to the exchange, empty to omit it.
+	Audience string
+	// Endpoint is the URL of the exchange, or a path relative to the host.
+	Endpoint string
+	// Getenv reads environment variables, os.Getenv when nil.
+	Getenv func(string) string
+}
+
+// Token exchanges the current OIDC token. The environment variable is read on
+// every exchange, so renewed tokens are picked up.
+func (s *OIDCTokenSource) Token(c *Client) (*AuthResponse, error) {
+	getenv := s.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+	subject := strings.TrimSpace(getenv(s.TokenEnv))
+	if subject == "" {
+		return nil, fmt.Errorf("environment variable %s holding the OIDC token is empty", s.TokenEnv)
+	}
+
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultOIDCExchangeEndpoint
+	}
+	if strings.HasPrefix(endpoint, "/") {
+		endpoint = c.HostURL + endpoint
+	}
+
+	body := map[string]string{
+		"subject_token":      subject,
+		"subject_token_type": jwtTokenType,
+	}
+	if s.Audience != "" {
+		body["audience"] = s.Audience
+	}
+	rb, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(rb))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// The OIDC token authenticates the exchange, the session of c must not.
+	res, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchanging the OIDC token: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("exchanging the OIDC token: %w", err)
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("exchanging the OIDC token: %w", statusError(res, resBody))
+	}
+
+	ar := AuthResponse{}
+	if err := json.Unmarshal(resBody, &ar); err != nil {
+		return nil, err
+	}
+	if ar.IdToken == "" {
+		return nil, fmt.Errorf("exchanging the OIDC token: the response holds no id_token")
+	}
+	return &ar, nil
+}
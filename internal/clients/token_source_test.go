@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeIssuer mints unsigned JWTs for an audience, like a CI OIDC provider.
+func fakeIssuer(audience string) string {
+	enc := base64.RawURLEncoding
+	claims, _ := json.Marshal(map[string]string{"iss": "https://ci.example.com", "sub": "project:infra:ref:main", "aud": audience})
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString(claims) + "."
+}
+
+// audienceOf returns the aud claim of a JWT minted by fakeIssuer.
+func audienceOf(jwt string) string {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Aud string `json:"aud"`
+	}
+	_ = json.Unmarshal(payload, &claims)
+	return claims.Aud
+}
+
+func TestOIDCTokenSource(t *testing.T) {
+	selected := "t1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == DefaultOIDCExchangeEndpoint:
+			if _, ok := r.Header["Authorization"]; ok {
+				http.Error(w, `{"error": "the exchange is authenticated by the subject token only"}`, http.StatusBadRequest)
+				return
+			}
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["subject_token_type"] != jwtTokenType || body["audience"] == "" || audienceOf(body["subject_token"]) != body["audience"] {
+				http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"id_token": "exchanged-` + selected + `", "refresh_token": "r"}`))
+		case r.URL.Path == "/ident/v1/tenant":
+			w.Write([]byte(`{"tenant_id": "t1"}`))
+		case strings.HasPrefix(r.URL.Path, "/ident/v1/tenant/select/"):
+			selected = strings.TrimPrefix(r.URL.Path, "/ident/v1/tenant/select/")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	env := map[string]string{"CI_JOB_JWT": fakeIssuer("sda")}
+	source := &OIDCTokenSource{TokenEnv: "CI_JOB_JWT", Audience: "sda", Getenv: func(k string) string { return env[k] }}
+
+	c, err := NewRestClient(context.Background(), server.URL, Credentials{Source: source}, "t2", nil, Limits{})
+	if err != nil {
+		t.Fatalf("NewRestClient: %s", err)
+	}
+	if c.IdToken != "exchanged-t2" || c.TenantID != "t2" {
+		t.Fatalf("client = %+v", c)
+	}
+
+	// Switching tenants exchanges the token again while the client holds a
+	// session.
+	if scoped, err := c.ForTenant("t3"); err != nil || scoped.IdToken != "exchanged-t3" {
+		t.Fatalf("ForTenant: %v, %v", scoped, err)
+	}
+
+	source.Audience = "other"
+	if _, err := source.Token(c); err == nil {
+		t.Fatalf("expected the exchange of a token for another audience to fail")
+	}
+
+	env["CI_JOB_JWT"] = ""
+	if _, err := source.Token(c); err == nil || !strings.Contains(err.Error(), "CI_JOB_JWT") {
+		t.Fatalf("expected an error naming the empty variable, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the OpenTelemetry instrumentation name of the provider.
+const TracerName = "github.com/sda/terraform-provider-sda"
+
+// RequestIDKey is the span attribute of the API gateway request ID.
+const RequestIDKey = attribute.Key("sda.request_id")
+
+// tracingTransport records a client span per HTTP call, as a child of the span
+// in the context of the request. Spans go to the global tracer provider,
+// which is a no-op unless tracing is enabled.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+// newTracingTransport returns a tracingTransport sending requests with base.
+func newTracingTransport(base http.RoundTripper) *tracingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &tracingTransport{base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(TracerName).Start(req.Context(), req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(redactURL(req.URL)),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	if id := requestID(res.Header); id != "" {
+		span.SetAttributes(RequestIDKey.String(id))
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, res.Status)
+	}
+	return res, nil
+}
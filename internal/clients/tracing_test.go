@@ -0,0 +1,68 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+func TestTracingTransport(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-amzn-RequestId", "req-123")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := &Client{HostURL: server.URL, HTTPClient: &http.Client{Transport: newTracingTransport(nil)}}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "sda_device read")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/assets/v1/device/d1", nil)
+	if _, err := c.DoRequest(req, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "GET /assets/v1/device/d1" {
+		t.Errorf("span name = %q", span.Name)
+	}
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("the HTTP span is not a child of the operation span")
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("status = %v, want error", span.Status)
+	}
+
+	want := map[string]string{
+		string(semconv.HTTPResponseStatusCodeKey): "500",
+		string(RequestIDKey):                      "req-123",
+	}
+	for _, attr := range span.Attributes {
+		if v, ok := want[string(attr.Key)]; ok {
+			if attr.Value.Emit() != v {
+				t.Errorf("%s = %s, want %s", attr.Key, attr.Value.Emit(), v)
+			}
+			delete(want, string(attr.Key))
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("missing attributes %v", want)
+	}
+}
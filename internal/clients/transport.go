@@ -0,0 +1,198 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpSubsystem is the tflog subsystem of HTTP calls. Its level is set with
+// TF_LOG_PROVIDER_SDA_HTTP.
+const httpSubsystem = "sda_http"
+
+// httpLogLevelEnv sets the level of the sda_http subsystem.
+const httpLogLevelEnv = "TF_LOG_PROVIDER_SDA_HTTP"
+
+// maxLoggedBody bounds the number of body bytes logged per request or response.
+// Longer bodies are logged by size only.
+const maxLoggedBody = 16 * 1024
+
+// requestIDHeaders are the response headers of the API gateway that identify
+// a request, in order of preference.
+var requestIDHeaders = []string{"x-amzn-RequestId", "x-amz-apigw-id", "apigw-requestid"}
+
+// sensitiveBodyKeys are JSON keys whose values are redacted from logged bodies.
+var sensitiveBodyKeys = map[string]bool{
+	"password": true, "password_confirmation": true, "secret_value": true,
+	"token": true, "id_token": true, "access_token": true, "refresh_token": true,
+	"subject_token": true, "confirmation_code": true,
+}
+
+// sensitiveQueryKeys are query parameters redacted from logged URLs, such as
+// the signature of presigned S3 URLs.
+var sensitiveQueryKeys = []string{"X-Amz-Signature", "X-Amz-Credential", "X-Amz-Security-Token"}
+
+// requestID returns the API gateway request ID of a response, empty if the
+// response has none.
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// httpLogContext returns ctx with the sda_http subsystem, with the same
+// masking of credentials as the provider logger.
+func httpLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpSubsystem, tflog.WithLevelFromEnv(httpLogLevelEnv))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, httpSubsystem, sensitiveFields...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, httpSubsystem, jwtPattern)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, httpSubsystem, jwtPattern)
+	return ctx
+}
+
+// traceBodies reports whether the log level asks for bodies, which are only
+// read for logging at TRACE level.
+func traceBodies(getenv func(string) string) bool {
+	for _, env := range []string{httpLogLevelEnv, "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := getenv(env); level != "" {
+			return strings.EqualFold(level, "TRACE")
+		}
+	}
+	return false
+}
+
+// loggingTransport logs the method, URL, status, latency and request ID of
+// each HTTP call in the sda_http subsystem, and the redacted bodies at TRACE
+// level.
+type loggingTransport struct {
+	base   http.RoundTripper
+	ctx    context.Context
+	bodies bool
+}
+
+// newLoggingTransport returns a loggingTransport logging with the logger of ctx.
+func newLoggingTransport(ctx context.Context, base http.RoundTripper) *loggingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &loggingTransport{base: base, ctx: httpLogContext(ctx), bodies: traceBodies(os.Getenv)}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]any{
+		"method": req.Method,
+		"url":    redactURL(req.URL),
+	}
+	if t.bodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			head, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+			body.Close()
+			tflog.SubsystemTrace(t.ctx, httpSubsystem, "Sending HTTP request body", map[string]any{
+				"method": req.Method,
+				"url":    redactURL(req.URL),
+				"body":   redactBody(head),
+			})
+		}
+	}
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(t.ctx, httpSubsystem, "HTTP request failed", fields)
+		return nil, err
+	}
+
+	fields["status"] = res.StatusCode
+	if id := requestID(res.Header); id != "" {
+		fields["request_id"] = id
+	}
+	tflog.SubsystemDebug(t.ctx, httpSubsystem, "HTTP request", fields)
+
+	if t.bodies && res.Body != nil {
+		head, _ := io.ReadAll(io.LimitReader(res.Body, maxLoggedBody+1))
+		// The caller still reads the whole body.
+		res.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), res.Body), res.Body}
+		tflog.SubsystemTrace(t.ctx, httpSubsystem, "Received HTTP response body", map[string]any{
+			"status":     res.StatusCode,
+			"request_id": requestID(res.Header),
+			"body":       redactBody(head),
+		})
+	}
+	return res, nil
+}
+
+// redactURL returns u with the values of sensitive query parameters redacted.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, key := range sensitiveQueryKeys {
+		if query.Has(key) {
+			query.Set(key, "***")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	c := *u
+	c.RawQuery = query.Encode()
+	return c.String()
+}
+
+// redactBody returns body with the values of sensitive JSON keys redacted.
+// Secrets cannot be found in bodies longer than maxLoggedBody, which are read
+// only in part, nor in bodies that are not JSON, so only their size is logged.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if len(body) > maxLoggedBody {
+		return fmt.Sprintf("(more than %d bytes, not logged)", maxLoggedBody)
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("(%d bytes, not JSON, not logged)", len(body))
+	}
+	b, err := json.Marshal(redactJSON(v))
+	if err != nil {
+		return fmt.Sprintf("(%d bytes, not logged)", len(body))
+	}
+	return string(b)
+}
+
+// redactJSON replaces the values of sensitive keys in a decoded JSON value.
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitiveBodyKeys[strings.ToLower(key)] {
+				v[key] = "***"
+				continue
+			}
+			v[key] = redactJSON(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+	}
+	return v
+}
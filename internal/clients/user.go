@@ -0,0 +1,31 @@
+package clients
+
+// ConfirmUserRequest - Body of a user sign up confirmation.
+type ConfirmUserRequest struct {
+	Username         string `json:"username"`
+	ConfirmationCode string `json:"confirmation_code"`
+}
+
+// ForgotPasswordRequest - Body of a password reset request.
+type ForgotPasswordRequest struct {
+	Username string `json:"username"`
+}
+
+// ForgotPasswordResponse -
+type ForgotPasswordResponse struct {
+	IsPasswordReset bool                   `json:"is_password_reset"`
+	NextStep        ForgotPasswordNextStep `json:"next_step"`
+}
+
+// ForgotPasswordNextStep -
+type ForgotPasswordNextStep struct {
+	CodeDeliveryDetails CodeDeliveryDetails `json:"code_delivery_details"`
+	ResetPasswordStep   string              `json:"reset_password_step"`
+}
+
+// CodeDeliveryDetails - Where the code of a password reset was sent.
+type CodeDeliveryDetails struct {
+	AttributeName  string `json:"attribute_name"`
+	DeliveryMedium string `json:"delivery_medium"`
+	Destination    string `json:"destination"`
+}
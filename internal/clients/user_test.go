@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestForgotPassword(t *testing.T) {
+	var got ForgotPasswordRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ident/v1/user/forgot_password" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"is_password_reset": false, "next_step": {"reset_password_step": "CONFIRM_RESET_PASSWORD_WITH_CODE",
+			"code_delivery_details": {"attribute_name": "email", "delivery_medium": "EMAIL", "destination": "j***@e***"}}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	resp, err := c.ForgotPassword(ForgotPasswordRequest{Username: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("ForgotPassword: %s", err)
+	}
+	if got.Username != "jane@example.com" {
+		t.Fatalf("request = %+v", got)
+	}
+	details := resp.NextStep.CodeDeliveryDetails
+	if details.DeliveryMedium != "EMAIL" || details.Destination != "j***@e***" {
+		t.Fatalf("code delivery details = %+v", details)
+	}
+
+	if err := c.ConfirmUser(ConfirmUserRequest{Username: "jane@example.com"}); err == nil {
+		t.Fatalf("expected an error confirming without a code")
+	}
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+// authSetting is a provider attribute of an authentication method.
+type authSetting struct {
+	attribute path.Path
+	label     string
+	envVar    string
+	config    types.String
+}
+
+// value returns the configured value of s, or else its environment variable.
+func (s authSetting) value(getenv func(string) string) string {
+	if !s.config.IsNull() {
+		return s.config.ValueString()
+	}
+	return getenv(s.envVar)
+}
+
+// authMethod is a way for the provider to authenticate.
+type authMethod struct {
+	name string
+	// declared is set when a block of the method is configured.
+	declared bool
+	settings []authSetting
+}
+
+// authMethods returns the authentication methods of config.
+func authMethods(config SDAProviderModel) []authMethod {
+	oidc := OIDCModel{TokenEnv: types.StringNull(), Audience: types.StringNull(), ExchangeEndpoint: types.StringNull()}
+	if config.OIDC != nil {
+		oidc = *config.OIDC
+	}
+
+	return []authMethod{
+		{name: "username and password", settings: []authSetting{
+			{attribute: path.Root("username"), label: "Username", envVar: "SDA_USERNAME", config: config.Username},
+			{attribute: path.Root("password"), label: "Password", envVar: "SDA_PASSWORD", config: config.Password},
+		}},
+		{name: "token", settings: []authSetting{
+			{attribute: path.Root("token"), label: "Token", envVar: "SDA_TOKEN", config: config.Token},
+		}},
+		{name: "token_file", settings: []authSetting{
+			{attribute: path.Root("token_file"), label: "Token File", envVar: "SDA_TOKEN_FILE", config: config.TokenFile},
+		}},
+		{name: "refresh_token", settings: []authSetting{
+			{attribute: path.Root("refresh_token"), label: "Refresh Token", envVar: "SDA_REFRESH_TOKEN", config: config.RefreshToken},
+		}},
+		{name: "credential_process", settings: []authSetting{
+			{attribute: path.Root("credential_process"), label: "Credential Process", envVar: "SDA_CREDENTIAL_PROCESS", config: config.CredentialProcess},
+		}},
+		{name: "oidc", declared: config.OIDC != nil, settings: []authSetting{
+			{attribute: path.Root("oidc").AtName("token_env"), label: "OIDC Token Environment Variable", envVar: "SDA_OIDC_TOKEN_ENV", config: oidc.TokenEnv},
+		}},
+	}
+}
+
+// describeAuthMethods lists methods with the environment variables that set
+// them, for error messages.
+func describeAuthMethods(methods []authMethod) string {
+	descriptions := make([]string, len(methods))
+	for i, m := range methods {
+		envVars := make([]string, len(m.settings))
+		for j, s := range m.settings {
+			envVars[j] = s.envVar
+		}
+		descriptions[i] = fmt.Sprintf("%s (%s)", m.name, strings.Join(envVars, " and "))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// inConfig reports whether a setting of m is set in the configuration.
+func (m authMethod) inConfig() bool {
+	if m.declared {
+		return true
+	}
+	for _, s := range m.settings {
+		if !s.config.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// inEnv reports whether a setting of m is set through its environment variable.
+func (m authMethod) inEnv(getenv func(string) string) bool {
+	for _, s := range m.settings {
+		if getenv(s.envVar) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveCredentials returns the credentials of the single authentication
+// method that is configured. Methods set in the provider configuration take
+// precedence over methods only set through environment variables or the
+// profile, which getenv falls back to.
+func resolveCredentials(ctx context.Context, config SDAProviderModel, getenv func(string) string) (clients.Credentials, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var creds clients.Credentials
+
+	var fromConfig, fromEnv []authMethod
+	for _, m := range authMethods(config) {
+		switch {
+		case m.inConfig():
+			fromConfig = append(fromConfig, m)
+		case m.inEnv(getenv):
+			fromEnv = append(fromEnv, m)
+		}
+	}
+	methods := fromConfig
+	if len(methods) == 0 {
+		methods = fromEnv
+	}
+
+	switch len(methods) {
+	case 0:
+		diags.AddError(
+			"Missing SDA API Credentials",
+			"The provider cannot create the SDA API client as no authentication method is configured. "+
+				"Configure exactly one of: "+describeAuthMethods(authMethods(config))+", "+
+				"in the provider configuration, through the environment variables in parentheses, "+
+				"or in a profile of the shared credentials file selected with profile (SDA_PROFILE).",
+		)
+		return creds, "", diags
+	case 1:
+	default:
+		names := make([]string, len(methods))
+		for i, m := range methods {
+			names[i] = m.name
+		}
+		diags.AddError(
+			"Conflicting SDA API Credentials",
+			fmt.Sprintf("The provider cannot create the SDA API client as more than one authentication method is configured: %s. "+
+				"Configure exactly one of them, in the provider configuration or through environment variables and the profile.", strings.Join(names, ", ")),
+		)
+		return creds, "", diags
+	}
+
+	m := methods[0]
+	values := make([]string, len(m.settings))
+	for i, setting := range m.settings {
+		values[i] = setting.value(getenv)
+		if values[i] == "" {
+			diags.AddAttributeError(
+				setting.attribute,
+				"Missing SDA API "+setting.label,
+				fmt.Sprintf("The provider cannot create the SDA API client as there is a missing or empty value for the SDA API %s. "+
+					"Set the %s value in the configuration or use the %s environment variable. "+
+					"If either is already set, ensure the value is not empty.", strings.ToLower(setting.label), setting.attribute, setting.envVar),
+			)
+		}
+	}
+	if diags.HasError() {
+		return creds, "", diags
+	}
+
+	switch m.name {
+	case "username and password":
+		creds.Username, creds.Password = values[0], values[1]
+	case "token":
+		creds.Token = values[0]
+	case "token_file":
+		b, err := os.ReadFile(values[0])
+		if err != nil {
+			diags.AddAttributeError(path.Root("token_file"), "Unreadable SDA API Token File",
+				fmt.Sprintf("The provider cannot read the token file %s: %s", values[0], err))
+			return creds, "", diags
+		}
+		creds.Token = strings.TrimSpace(string(b))
+		if creds.Token == "" {
+			diags.AddAttributeError(path.Root("token_file"), "Empty SDA API Token File",
+				fmt.Sprintf("The token file %s is empty.", values[0]))
+			return creds, "", diags
+		}
+	case "refresh_token":
+		creds.RefreshToken = values[0]
+	case "credential_process":
+		var err error
+		if creds, err = runCredentialProcess(ctx, values[0]); err != nil {
+			diags.AddAttributeError(path.Root("credential_process"), "SDA Credential Process Failed",
+				fmt.Sprintf("The provider cannot obtain credentials from the credential process: %s", err))
+			return creds, "", diags
+		}
+	case "oidc":
+		source := &clients.OIDCTokenSource{TokenEnv: values[0], Audience: getenv("SDA_OIDC_AUDIENCE"), Endpoint: clients.DefaultOIDCExchangeEndpoint, Getenv: getenv}
+		if config.OIDC != nil && !config.OIDC.Audience.IsNull() {
+			source.Audience = config.OIDC.Audience.ValueString()
+		}
+		if config.OIDC != nil && !config.OIDC.ExchangeEndpoint.IsNull() {
+			source.Endpoint = config.OIDC.ExchangeEndpoint.ValueString()
+		}
+		creds.Source = source
+	}
+	return creds, m.name, diags
+}
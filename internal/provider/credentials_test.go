@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveCredentials(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	null := SDAProviderModel{
+		Username: types.StringNull(), Password: types.StringNull(),
+		Token: types.StringNull(), TokenFile: types.StringNull(), RefreshToken: types.StringNull(), CredentialProcess: types.StringNull(),
+	}
+	with := func(f func(*SDAProviderModel)) SDAProviderModel {
+		c := null
+		f(&c)
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		config  SDAProviderModel
+		env     map[string]string
+		method  string
+		wantErr bool
+	}{
+		{name: "none", config: null, wantErr: true},
+		{name: "password from env", config: null, env: map[string]string{"SDA_USERNAME": "u", "SDA_PASSWORD": "p"}, method: "username and password"},
+		{name: "username in config, password from env", config: with(func(c *SDAProviderModel) { c.Username = types.StringValue("u") }), env: map[string]string{"SDA_PASSWORD": "p"}, method: "username and password"},
+		{name: "missing password", config: with(func(c *SDAProviderModel) { c.Username = types.StringValue("u") }), wantErr: true},
+		{name: "token from env", config: null, env: map[string]string{"SDA_TOKEN": "t"}, method: "token"},
+		{name: "config takes precedence", config: with(func(c *SDAProviderModel) { c.Token = types.StringValue("t") }), env: map[string]string{"SDA_USERNAME": "u", "SDA_PASSWORD": "p"}, method: "token"},
+		{name: "conflicting env", config: null, env: map[string]string{"SDA_TOKEN": "t", "SDA_REFRESH_TOKEN": "r"}, wantErr: true},
+		{name: "conflicting config", config: with(func(c *SDAProviderModel) {
+			c.Token = types.StringValue("t")
+			c.RefreshToken = types.StringValue("r")
+		}), wantErr: true},
+		{name: "token file", config: with(func(c *SDAProviderModel) { c.TokenFile = types.StringValue(tokenFile) }), method: "token_file"},
+		{name: "missing token file", config: with(func(c *SDAProviderModel) { c.TokenFile = types.StringValue(tokenFile + ".missing") }), wantErr: true},
+		{name: "refresh token", config: null, env: map[string]string{"SDA_REFRESH_TOKEN": "r"}, method: "refresh_token"},
+		{name: "oidc block", config: with(func(c *SDAProviderModel) {
+			c.OIDC = &OIDCModel{TokenEnv: types.StringValue("CI_JOB_JWT"), Audience: types.StringValue("sda"), ExchangeEndpoint: types.StringNull()}
+		}), env: map[string]string{"SDA_USERNAME": "u", "SDA_PASSWORD": "p"}, method: "oidc"},
+		{name: "empty oidc block", config: with(func(c *SDAProviderModel) {
+			c.OIDC = &OIDCModel{TokenEnv: types.StringNull(), Audience: types.StringNull(), ExchangeEndpoint: types.StringNull()}
+		}), env: map[string]string{"SDA_OIDC_TOKEN_ENV": "CI_JOB_JWT"}, method: "oidc"},
+		{name: "oidc block without token_env", config: with(func(c *SDAProviderModel) {
+			c.OIDC = &OIDCModel{TokenEnv: types.StringNull(), Audience: types.StringNull(), ExchangeEndpoint: types.StringNull()}
+		}), wantErr: true},
+	}
+
+	_, _, diags := resolveCredentials(context.Background(), null, func(string) string { return "" })
+	for _, want := range []string{"SDA_USERNAME and SDA_PASSWORD", "token (SDA_TOKEN)", "SDA_TOKEN_FILE", "SDA_REFRESH_TOKEN", "credential_process (SDA_CREDENTIAL_PROCESS)", "oidc (SDA_OIDC_TOKEN_ENV)", "profile (SDA_PROFILE)"} {
+		if !diags.HasError() || !strings.Contains(diags[0].Detail(), want) {
+			t.Errorf("missing credentials error %v does not mention %s", diags, want)
+		}
+	}
+
+	for _, tt := range tests {
+		creds, method, diags := resolveCredentials(context.Background(), tt.config, func(k string) string { return tt.env[k] })
+		if diags.HasError() != tt.wantErr {
+			t.Errorf("%s: diagnostics = %v, wantErr %v", tt.name, diags, tt.wantErr)
+			continue
+		}
+		if method != tt.method {
+			t.Errorf("%s: method = %q, want %q", tt.name, method, tt.method)
+		}
+		if tt.method == "oidc" && creds.Source == nil {
+			t.Errorf("%s: no token source", tt.name)
+		}
+		if tt.method == "token_file" && creds.Token != "file-token" {
+			t.Errorf("%s: token = %q", tt.name, creds.Token)
+		}
+	}
+}
@@ -8,11 +8,18 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/metadata"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 //-----------------------------------------------------------------
@@ -20,6 +27,10 @@ import (
 //-----------------------------------------------------------------
 
 func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan DeviceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -45,11 +56,13 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	if !plan.GroupID.IsUnknown() && !plan.GroupID.IsNull() {
 		payload["group_id"] = plan.GroupID.ValueString()
 	}
-	if !plan.MetaData.IsUnknown() && !plan.MetaData.IsNull() {
-		var metaData map[string]interface{}
-		if err := json.Unmarshal([]byte(plan.MetaData.ValueString()), &metaData); err == nil {
-			payload["meta_data"] = metaData
-		}
+	metaData, diags := plannedMetaData(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if metaData != nil {
+		payload["meta_data"] = metaData
 	}
 	if !plan.DeviceType.IsUnknown() && !plan.DeviceType.IsNull() {
 		payload["device_type"] = plan.DeviceType.ValueString()
@@ -76,7 +89,7 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	url := fmt.Sprintf("%s/assets/v1/device", r.client.HostURL)
 
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
@@ -116,26 +129,32 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	state := buildDeviceState(ctx, &apiResp, &resp.Diagnostics)
+	state := buildDeviceState(ctx, &apiResp, !plan.MetaDataMap.IsNull(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DeviceIdentityModel{DeviceID: state.DeviceID})...)
 }
 
 //-----------------------------------------------------------------
 //         READ
 //-----------------------------------------------------------------
 func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state DeviceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DeviceIdentityModel{DeviceID: state.DeviceID})...)
 
 	url := fmt.Sprintf("%s/assets/v1/device/%s", r.client.HostURL, state.DeviceID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
@@ -167,7 +186,7 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	state = buildDeviceState(ctx, &apiResp, &resp.Diagnostics)
+	state = buildDeviceState(ctx, &apiResp, !state.MetaDataMap.IsNull(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -179,6 +198,10 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 //         UPDATE
 //-----------------------------------------------------------------
 func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state DeviceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -220,15 +243,13 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Include meta_data if changed
-	if !plan.MetaData.Equal(state.MetaData) {
-		if plan.MetaData.IsNull() {
-			payload["meta_data"] = nil
-		} else {
-			var metaData map[string]interface{}
-			if err := json.Unmarshal([]byte(plan.MetaData.ValueString()), &metaData); err == nil {
-				payload["meta_data"] = metaData
-			}
+	if !plan.MetaData.Equal(state.MetaData) || !plan.MetaDataMap.Equal(state.MetaDataMap) {
+		metaData, diags := plannedMetaData(plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		payload["meta_data"] = metaData
 	}
 
 	// Include description if changed
@@ -270,7 +291,7 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	url := fmt.Sprintf("%s/assets/v1/device/%s", r.client.HostURL, state.DeviceID.ValueString())
 
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
@@ -303,18 +324,23 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		apiResp.FtpConfig = nil
 	}
 
-	state = buildDeviceState(ctx, &apiResp, &resp.Diagnostics)
+	state = buildDeviceState(ctx, &apiResp, !plan.MetaDataMap.IsNull(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DeviceIdentityModel{DeviceID: state.DeviceID})...)
 }
 
 //-----------------------------------------------------------------
 //         DELETE
 //-----------------------------------------------------------------
 func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state DeviceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -322,7 +348,7 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/device/%s", r.client.HostURL, state.DeviceID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
@@ -339,11 +365,101 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+//-----------------------------------------------------------------
+//         PLAN MODIFICATION
+//-----------------------------------------------------------------
+
+// ModifyPlan warns when the planned ip_address is already used by another
+// device in the tenant. Terraform does not expose sibling resources to the
+// provider, so devices created in the same apply are only detected once they
+// exist.
+func (r *DeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var ipAddress, deviceID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("connection_configuration").AtName("ip_address"), &ipAddress)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("device_id"), &deviceID)...)
+	if resp.Diagnostics.HasError() || ipAddress.IsNull() || ipAddress.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateIPAddress types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("connection_configuration").AtName("ip_address"), &stateIPAddress)...)
+		if resp.Diagnostics.HasError() || stateIPAddress.Equal(ipAddress) {
+			return
+		}
+	}
+
+	devices, err := listDevices(ctx, r.client)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to list devices for duplicate IP check", map[string]any{"error": err.Error()})
+		return
+	}
+
+	for _, d := range devices {
+		if d.DeviceID == deviceID.ValueString() || d.ConnectionConfig.IPAddress != ipAddress.ValueString() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("connection_configuration").AtName("ip_address"),
+			"Duplicate Device IP Address",
+			fmt.Sprintf("IP address %s is already used by device %q (%s).", ipAddress.ValueString(), d.Name, d.DeviceID),
+		)
+	}
+}
+
 //-----------------------------------------------------------------
 //         HELPER FUNCTIONS
 //-----------------------------------------------------------------
 
-func buildDeviceState(ctx context.Context, apiResp *DeviceAPIResponse, diags *diag.Diagnostics) DeviceResourceModel {
+// listDevices returns every device of the tenant.
+func listDevices(ctx context.Context, client *clients.Client) ([]DeviceAPIResponse, error) {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/assets/v1/device", client.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []DeviceAPIResponse
+	if err := json.Unmarshal(resBody, &devices); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// plannedMetaData returns the meta data to send to the API from whichever of
+// meta_data or meta_data_map is configured, or nil when neither is set.
+func plannedMetaData(plan DeviceResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !plan.MetaDataMap.IsNull() && !plan.MetaDataMap.IsUnknown() {
+		value, err := metadata.DynamicToNative(plan.MetaDataMap)
+		metaData, ok := value.(map[string]interface{})
+		if err != nil || !ok {
+			diags.AddAttributeError(path.Root("meta_data_map"), "Invalid Meta Data", fmt.Sprintf("meta_data_map must be an object with known values: %v", err))
+			return nil, diags
+		}
+		return metaData, diags
+	}
+
+	if !plan.MetaData.IsNull() && !plan.MetaData.IsUnknown() {
+		var metaData map[string]interface{}
+		diags.Append(plan.MetaData.Unmarshal(&metaData)...)
+		return metaData, diags
+	}
+
+	return nil, diags
+}
+
+func buildDeviceState(ctx context.Context, apiResp *DeviceAPIResponse, metaDataAsMap bool, diags *diag.Diagnostics) DeviceResourceModel {
 	state := DeviceResourceModel{
 		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
 		CreationUserID:    types.StringValue(apiResp.CreationUserID),
@@ -371,16 +487,22 @@ func buildDeviceState(ctx context.Context, apiResp *DeviceAPIResponse, diags *di
 	diags.Append(diag...)
 	state.ConnectionConfig = connConfigObj
 
-	// Build metadata JSON string
-	if apiResp.MetaData != nil && len(apiResp.MetaData) > 0 {
+	// Build metadata in the representation chosen in the configuration
+	state.MetaData = jsontypes.NewNormalizedNull()
+	state.MetaDataMap = types.DynamicNull()
+	if len(apiResp.MetaData) > 0 {
 		metaDataJSON, err := json.Marshal(apiResp.MetaData)
-		if err == nil {
-			state.MetaData = types.StringValue(string(metaDataJSON))
+		if err != nil {
+			diags.AddError("Decode Error", fmt.Sprintf("Error encoding device meta_data: %s", err))
+		} else if metaDataAsMap {
+			metaDataMap, err := metadata.DynamicFromJSON(metaDataJSON)
+			if err != nil {
+				diags.AddError("Decode Error", fmt.Sprintf("Error converting device meta_data: %s", err))
+			}
+			state.MetaDataMap = metaDataMap
 		} else {
-			state.MetaData = types.StringNull()
+			state.MetaData = jsontypes.NewNormalizedValue(string(metaDataJSON))
 		}
-	} else {
-		state.MetaData = types.StringNull()
 	}
 
 	// Build FTP configuration object if present
@@ -0,0 +1,102 @@
+package device
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var _ list.ListResource = &DeviceListResource{}
+var _ list.ListResourceWithConfigure = &DeviceListResource{}
+
+func NewDeviceListResource() list.ListResource {
+	return &DeviceListResource{}
+}
+
+// DeviceListResource enumerates the devices of the tenant for `terraform query`.
+type DeviceListResource struct {
+	client *clients.Client
+}
+
+// DeviceListConfigModel maps the list block configuration.
+type DeviceListConfigModel struct {
+	GroupID types.String `tfsdk:"group_id"`
+}
+
+func (r *DeviceListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device"
+}
+
+func (r *DeviceListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the devices of the tenant in the SDA Assets Management Service.",
+		Attributes: map[string]listschema.Attribute{
+			"group_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list devices that belong to this resource group.",
+			},
+		},
+	}
+}
+
+func (r *DeviceListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *DeviceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+	if !tenancy.RequireClient(r.client, &diags) {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config DeviceListConfigModel
+	diags = req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	devices, err := listDevices(ctx, r.client)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Error listing devices: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, d := range devices {
+			if !config.GroupID.IsNull() && (d.GroupID == nil || *d.GroupID != config.GroupID.ValueString()) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = d.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, DeviceIdentityModel{DeviceID: types.StringValue(d.DeviceID)})...)
+			if req.IncludeResource {
+				state := buildDeviceState(ctx, &d, false, &result.Diagnostics)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
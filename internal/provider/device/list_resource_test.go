@@ -0,0 +1,84 @@
+package device
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestDeviceListResource(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"device_id": "d1", "group_id": "g1", "name": "plc-01", "vendor_id": "v", "ide_config_id": "i", "device_type": "PLC",
+			 "creation_user_id": "u", "creation_timestamp": "2025-01-01T00:00:00Z",
+			 "connection_configuration": {"ip_address": "10.0.0.1", "port": 102}},
+			{"device_id": "d2", "group_id": "g2", "name": "plc-02", "vendor_id": "v", "ide_config_id": "i", "device_type": "PLC",
+			 "creation_user_id": "u", "creation_timestamp": "2025-01-01T00:00:00Z",
+			 "connection_configuration": {"ip_address": "10.0.0.2", "port": 102}}
+		]`))
+	}))
+	defer server.Close()
+
+	lr := &DeviceListResource{client: &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}}
+	r := &DeviceResource{}
+
+	var configSchema list.ListResourceSchemaResponse
+	lr.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchema)
+	var resourceSchema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	var identitySchema resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	config := tfsdk.Config{
+		Schema: configSchema.Schema,
+		Raw: tftypes.NewValue(configSchema.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"group_id": tftypes.NewValue(tftypes.String, "g2"),
+		}),
+	}
+
+	stream := &list.ListResultsStream{}
+	lr.List(ctx, list.ListRequest{
+		Config:                 config,
+		IncludeResource:        true,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", results[0].Diagnostics)
+	}
+	if results[0].DisplayName != "plc-02" {
+		t.Errorf("expected display name plc-02, got %q", results[0].DisplayName)
+	}
+
+	var identity DeviceIdentityModel
+	results[0].Identity.Get(ctx, &identity)
+	if identity.DeviceID != types.StringValue("d2") {
+		t.Errorf("expected identity device_id d2, got %s", identity.DeviceID)
+	}
+
+	var state DeviceResourceModel
+	results[0].Resource.Get(ctx, &state)
+	if state.Name.ValueString() != "plc-02" || state.ConnectionConfig.IsNull() {
+		t.Errorf("unexpected listed resource: %+v", state)
+	}
+}
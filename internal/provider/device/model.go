@@ -1,26 +1,28 @@
 package device
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type DeviceResourceModel struct {
-	DeviceID          types.String `tfsdk:"device_id"`
-	GroupID           types.String `tfsdk:"group_id"`
-	Name              types.String `tfsdk:"name"`
-	VendorID          types.String `tfsdk:"vendor_id"`
-	IdeConfigID       types.String `tfsdk:"ide_config_id"`
-	ConnectionConfig  types.Object `tfsdk:"connection_configuration"`
-	MetaData          types.String `tfsdk:"meta_data"`
-	DeviceType        types.String `tfsdk:"device_type"`
-	Description       types.String `tfsdk:"description"`
-	SecretID          types.String `tfsdk:"secret_id"`
-	FtpConfig         types.Object `tfsdk:"ftp_configuration"`
-	ObjectVersion     types.Int64  `tfsdk:"object_version"`
-	CreationUserID    types.String `tfsdk:"creation_user_id"`
-	UpdateUserID      types.String `tfsdk:"update_user_id"`
-	CreationTimestamp types.String `tfsdk:"creation_timestamp"`
-	UpdateTimestamp   types.String `tfsdk:"update_timestamp"`
+	DeviceID          types.String         `tfsdk:"device_id"`
+	GroupID           types.String         `tfsdk:"group_id"`
+	Name              types.String         `tfsdk:"name"`
+	VendorID          types.String         `tfsdk:"vendor_id"`
+	IdeConfigID       types.String         `tfsdk:"ide_config_id"`
+	ConnectionConfig  types.Object         `tfsdk:"connection_configuration"`
+	MetaData          jsontypes.Normalized `tfsdk:"meta_data"`
+	MetaDataMap       types.Dynamic        `tfsdk:"meta_data_map"`
+	DeviceType        types.String         `tfsdk:"device_type"`
+	Description       types.String         `tfsdk:"description"`
+	SecretID          types.String         `tfsdk:"secret_id"`
+	FtpConfig         types.Object         `tfsdk:"ftp_configuration"`
+	ObjectVersion     types.Int64          `tfsdk:"object_version"`
+	CreationUserID    types.String         `tfsdk:"creation_user_id"`
+	UpdateUserID      types.String         `tfsdk:"update_user_id"`
+	CreationTimestamp types.String         `tfsdk:"creation_timestamp"`
+	UpdateTimestamp   types.String         `tfsdk:"update_timestamp"`
 }
 
 type ConnectionConfiguration struct {
@@ -63,3 +65,8 @@ type DeviceAPIResponse struct {
 	SecretID          *string                 `json:"secret_id"`
 	FtpConfig         *FtpConfiguration       `json:"ftp_configuration,omitempty"`
 }
+
+// DeviceIdentityModel maps the resource identity schema used by import blocks.
+type DeviceIdentityModel struct {
+	DeviceID types.String `tfsdk:"device_id"`
+}
@@ -3,21 +3,36 @@ package device
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
+	"github.com/sda/terraform-provider-sda/internal/provider/validators"
 )
 
 var _ resource.Resource = &DeviceResource{}
 var _ resource.ResourceWithImportState = &DeviceResource{}
+var _ resource.ResourceWithIdentity = &DeviceResource{}
+var _ resource.ResourceWithConfigValidators = &DeviceResource{}
+var _ resource.ResourceWithModifyPlan = &DeviceResource{}
+
+// importLookup resolves device:<name> and group:<name>/device:<name> import IDs.
+var importLookup = importid.Collection{Kind: "device", Path: "/assets/v1/device", IDField: "device_id", Parent: &importid.ResourceGroups, ParentField: "group_id"}
 
 func NewDeviceResource() resource.Resource {
 	return &DeviceResource{}
@@ -68,33 +83,60 @@ func (r *DeviceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					"ip_address": schema.StringAttribute{
 						Required:    true,
 						Description: "IP address of the device.",
+						Validators: []validator.String{
+							validators.IPAddress(),
+						},
 					},
 					"port": schema.Int64Attribute{
 						Required:    true,
-						Description: "Port number for the device connection.",
+						Description: "Port number for the device connection (1-65535).",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
 					},
 					"subnet_mask": schema.StringAttribute{
 						Optional:    true,
-						Description: "Subnet mask for the device.",
+						Description: "Subnet mask for the device, as a dotted-decimal mask (255.255.255.0) or a prefix length (24).",
+						Validators: []validator.String{
+							validators.SubnetMask(),
+						},
 					},
 					"gateway_ip_address": schema.StringAttribute{
 						Optional:    true,
-						Description: "Gateway IP address for the device.",
+						Description: "Gateway IP address for the device. Must be inside the network given by ip_address and subnet_mask.",
+						Validators: []validator.String{
+							validators.IPAddress(),
+						},
 					},
 				},
 			},
 			"meta_data": schema.StringAttribute{
 				Optional:    true,
-				Description: "Metadata for the device in JSON format.",
+				CustomType:  jsontypes.NormalizedType{},
+				Description: "Metadata for the device as a JSON object. Key order and whitespace are ignored when comparing. Conflicts with meta_data_map.",
+				Validators: []validator.String{
+					validators.JSONObject(),
+					stringvalidator.ConflictsWith(path.MatchRoot("meta_data_map")),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"meta_data_map": schema.DynamicAttribute{
+				Optional:    true,
+				Description: "Metadata for the device as a Terraform object, as an alternative to the JSON encoded meta_data. Conflicts with meta_data.",
+				Validators: []validator.Dynamic{
+					dynamicvalidator.ConflictsWith(path.MatchRoot("meta_data")),
+				},
+			},
 			"device_type": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "Type of device (PLC, IPC, HMI, AGV, ROBOT, DRIVE, OTHER).",
-				Default:     stringdefault.StaticString("PLC"),
+				Validators: []validator.String{
+					enums.OneOf(enums.DeviceTypeEnum),
+				},
+				Default: stringdefault.StaticString("PLC"),
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
@@ -117,16 +159,25 @@ func (r *DeviceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					"ip_address": schema.StringAttribute{
 						Required:    true,
 						Description: "IP address of the FTP server on the device.",
+						Validators: []validator.String{
+							validators.IPAddress(),
+						},
 					},
 					"port": schema.Int64Attribute{
 						Optional:    true,
 						Computed:    true,
 						Description: "Port of the FTP server on the device.",
-						Default:     int64default.StaticInt64(22),
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+						Default: int64default.StaticInt64(22),
 					},
 					"protocol": schema.StringAttribute{
 						Optional:    true,
 						Description: "Protocol used by the FTP server (FTP, SFTP).",
+						Validators: []validator.String{
+							enums.OneOf(enums.FtpProtocolEnum),
+						},
 					},
 					"secret_id": schema.StringAttribute{
 						Optional:    true,
@@ -169,8 +220,25 @@ func (r *DeviceResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *DeviceResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validators.GatewayInSubnet(path.Root("connection_configuration")),
+	}
+}
+
+func (r *DeviceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"device_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the device.",
+			},
+		},
+	}
+}
+
 func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("device_id"), req, resp)
+	importid.ImportState(ctx, r.client, importLookup, path.Root("device_id"), req, resp)
 }
 
 // Helper function to get connection configuration object type
@@ -15,6 +15,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 const multipartChunkSize = 5 * 1024 * 1024 // 5MB per part
@@ -24,6 +26,10 @@ const multipartChunkSize = 5 * 1024 * 1024 // 5MB per part
 //-----------------------------------------------------------------
 
 func (r *DocumentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan DocumentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -104,7 +110,7 @@ func (r *DocumentResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/document", r.client.HostURL)
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
@@ -157,7 +163,7 @@ func (r *DocumentResource) Create(ctx context.Context, req resource.CreateReques
 		chunk := chunks[i]
 
 		// Upload chunk
-		uploadReq, err := http.NewRequest(http.MethodPut, uploadURL.UploadURL, bytes.NewReader(chunk))
+		uploadReq, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL.UploadURL, bytes.NewReader(chunk))
 		if err != nil {
 			resp.Diagnostics.AddError("Upload Error", fmt.Sprintf("Error creating upload request: %s", err))
 			return
@@ -165,7 +171,7 @@ func (r *DocumentResource) Create(ctx context.Context, req resource.CreateReques
 
 		// MD5 is already included in the pre-signed URL, no need to set it in headers
 
-		uploadResp, err := http.DefaultClient.Do(uploadReq)
+		uploadResp, err := r.client.Upload(uploadReq)
 		if err != nil {
 			resp.Diagnostics.AddError("Upload Error", fmt.Sprintf("Error uploading part %d: %s", uploadURL.PartNumber, err))
 			return
@@ -210,7 +216,7 @@ func (r *DocumentResource) Create(ctx context.Context, req resource.CreateReques
 		createResp.UploadID,
 	)
 
-	completeReq, err := http.NewRequest(http.MethodPost, completeURL, bytes.NewReader(completeBody))
+	completeReq, err := http.NewRequestWithContext(ctx, http.MethodPost, completeURL, bytes.NewReader(completeBody))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating complete request: %s", err))
 		return
@@ -242,6 +248,7 @@ func (r *DocumentResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DocumentIdentityModel{DocumentID: state.DocumentID})...)
 }
 
 // -----------------------------------------------------------------
@@ -250,14 +257,19 @@ func (r *DocumentResource) Create(ctx context.Context, req resource.CreateReques
 //
 // -----------------------------------------------------------------
 func (r *DocumentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state DocumentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DocumentIdentityModel{DocumentID: state.DocumentID})...)
 
 	url := fmt.Sprintf("%s/assets/v1/document/%s", r.client.HostURL, state.DocumentID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
@@ -311,6 +323,10 @@ func (r *DocumentResource) Read(ctx context.Context, req resource.ReadRequest, r
 //
 // -----------------------------------------------------------------
 func (r *DocumentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state DocumentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -354,7 +370,7 @@ func (r *DocumentResource) Update(ctx context.Context, req resource.UpdateReques
 
 	url := fmt.Sprintf("%s/assets/v1/document/%s", r.client.HostURL, state.DocumentID.ValueString())
 
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
@@ -390,6 +406,7 @@ func (r *DocumentResource) Update(ctx context.Context, req resource.UpdateReques
 	state.LastVersionNumber = types.Int64Value(apiResp.LastVersionNumber)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DocumentIdentityModel{DocumentID: state.DocumentID})...)
 }
 
 // -----------------------------------------------------------------
@@ -398,6 +415,10 @@ func (r *DocumentResource) Update(ctx context.Context, req resource.UpdateReques
 //
 // -----------------------------------------------------------------
 func (r *DocumentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state DocumentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -405,7 +426,7 @@ func (r *DocumentResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/document/%s", r.client.HostURL, state.DocumentID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
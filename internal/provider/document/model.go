@@ -64,3 +64,8 @@ type CompleteMultipartUploadRequest struct {
 	Parts    []S3MultipartCompleteInfo `json:"parts"`
 	FileName string                    `json:"file_name"`
 }
+
+// DocumentIdentityModel maps the resource identity schema used by import blocks.
+type DocumentIdentityModel struct {
+	DocumentID types.String `tfsdk:"document_id"`
+}
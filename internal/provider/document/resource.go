@@ -5,16 +5,24 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
 )
 
 var _ resource.Resource = &DocumentResource{}
 var _ resource.ResourceWithImportState = &DocumentResource{}
+var _ resource.ResourceWithIdentity = &DocumentResource{}
+
+// importLookup resolves document:<name> and group:<name>/document:<name> import IDs.
+var importLookup = importid.Collection{Kind: "document", Path: "/assets/v1/document", IDField: "document_id", Parent: &importid.ResourceGroups, ParentField: "group_id"}
 
 func NewDocumentResource() resource.Resource {
 	return &DocumentResource{}
@@ -53,6 +61,9 @@ func (r *DocumentResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"document_type": schema.StringAttribute{
 				Required:    true,
 				Description: "Type of document (PDF, MD, CSV, DOCX, TXT, XML, HTML, JSON, OTHERS).",
+				Validators: []validator.String{
+					enums.OneOf(enums.DocumentTypeEnum),
+				},
 			},
 			"last_version_number": schema.Int64Attribute{
 				Computed:    true,
@@ -117,6 +128,17 @@ func (r *DocumentResource) Configure(_ context.Context, req resource.ConfigureRe
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *DocumentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"document_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the document.",
+			},
+		},
+	}
+}
+
 func (r *DocumentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("document_id"), req, resp)
+	importid.ImportState(ctx, r.client, importLookup, path.Root("document_id"), req, resp)
 }
@@ -0,0 +1,16 @@
+// Package enums holds the enumerations of the SDA Assets Management Service
+// API, generated from docs/assets.yaml, and the schema validators built on
+// top of them.
+package enums
+
+//go:generate go run ./gen -spec ../../../docs/assets.yaml -out enums_gen.go
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// OneOf returns a validator that accepts only the given enum values.
+func OneOf(values []string) validator.String {
+	return stringvalidator.OneOf(values...)
+}
@@ -0,0 +1,81 @@
+// Code generated by internal/provider/enums/gen from docs/assets.yaml; DO NOT EDIT.
+
+package enums
+
+// AssetTypeEnum lists the values of the AssetTypeEnum OpenAPI schema.
+var AssetTypeEnum = []string{
+	"DEVICE",
+	"DOCUMENT",
+	"DOCUMENT_VERSION",
+	"GATEWAY",
+	"LICENSE",
+	"PROJECT",
+	"PROJECT_VERSION",
+	"RESOURCE_GROUP",
+	"TAG",
+	"VAULT",
+	"SECRET",
+	"SECRET_VERSION",
+	"PIPELINE",
+}
+
+// DeviceTypeEnum lists the values of the DeviceTypeEnum OpenAPI schema.
+var DeviceTypeEnum = []string{
+	"PLC",
+	"IPC",
+	"HMI",
+	"AGV",
+	"ROBOT",
+	"DRIVE",
+	"OTHER",
+}
+
+// DocumentTypeEnum lists the values of the DocumentTypeEnum OpenAPI schema.
+var DocumentTypeEnum = []string{
+	"PDF",
+	"MD",
+	"CSV",
+	"DOCX",
+	"TXT",
+	"XML",
+	"HTML",
+	"JSON",
+	"OTHERS",
+}
+
+// FtpProtocolEnum lists the values of the FtpProtocolEnum OpenAPI schema.
+var FtpProtocolEnum = []string{
+	"FTP",
+	"SFTP",
+}
+
+// LicenseStatusEnum lists the values of the LicenseStatusEnum OpenAPI schema.
+var LicenseStatusEnum = []string{
+	"REQUESTED",
+	"ACTIVE",
+	"UPLOADED",
+	"EXPIRED",
+	"INVALID",
+}
+
+// LicenseTypeEnum lists the values of the LicenseTypeEnum OpenAPI schema.
+var LicenseTypeEnum = []string{
+	"COOPERATE",
+	"FLOATING",
+	"SINGLE",
+	"UPGRADE",
+	"TRIAL",
+}
+
+// ProjectTypeEnum lists the values of the ProjectTypeEnum OpenAPI schema.
+var ProjectTypeEnum = []string{
+	"STANDARD",
+	"LIBRARY",
+	"GENERIC",
+}
+
+// ResourceGroupTypeEnum lists the values of the ResourceGroupTypeEnum OpenAPI schema.
+var ResourceGroupTypeEnum = []string{
+	"USER",
+	"ASSET",
+}
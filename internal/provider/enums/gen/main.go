@@ -0,0 +1,143 @@
+// Command gen generates the enum value lists in internal/provider/enums from
+// the OpenAPI specification of the SDA Assets Management Service.
+//
+// Usage (from internal/provider/enums):
+//
+//	go run ./gen -spec ../../../docs/assets.yaml -out enums_gen.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// schemaNames lists the OpenAPI component schemas exported as enums.
+var schemaNames = []string{
+	"AssetTypeEnum",
+	"DeviceTypeEnum",
+	"DocumentTypeEnum",
+	"FtpProtocolEnum",
+	"LicenseStatusEnum",
+	"LicenseTypeEnum",
+	"ProjectTypeEnum",
+	"ResourceGroupTypeEnum",
+}
+
+func main() {
+	specPath := flag.String("spec", "../../../docs/assets.yaml", "path to the OpenAPI specification")
+	outPath := flag.String("out", "enums_gen.go", "path of the generated Go file")
+	flag.Parse()
+
+	spec, err := os.Open(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer spec.Close()
+
+	src, err := generate(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate renders the Go source for the enums listed in schemaNames.
+func generate(spec io.Reader) ([]byte, error) {
+	enums, err := parseEnums(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/provider/enums/gen from docs/assets.yaml; DO NOT EDIT.\n\n")
+	buf.WriteString("package enums\n")
+
+	for _, name := range schemaNames {
+		values, ok := enums[name]
+		if !ok {
+			return nil, fmt.Errorf("schema %s not found or has no enum values", name)
+		}
+
+		fmt.Fprintf(&buf, "\n// %s lists the values of the %s OpenAPI schema.\n", name, name)
+		fmt.Fprintf(&buf, "var %s = []string{\n", name)
+		for _, v := range values {
+			fmt.Fprintf(&buf, "%q,\n", v)
+		}
+		buf.WriteString("}\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// parseEnums extracts the enum values of every string schema declared under
+// components.schemas. The spec is scanned line by line, which is sufficient
+// for the flat layout emitted by the SDA services.
+func parseEnums(spec io.Reader) (map[string][]string, error) {
+	const (
+		schemaIndent = "    "
+		fieldIndent  = "      "
+	)
+
+	enums := map[string][]string{}
+	inSchemas := false
+	current := ""
+	inEnum := false
+
+	scanner := bufio.NewScanner(spec)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " ")
+
+		switch {
+		case line == "  schemas:":
+			inSchemas = true
+			continue
+		case !inSchemas:
+			continue
+		case line != "" && !strings.HasPrefix(line, "  "):
+			// Left components.schemas
+			inSchemas = false
+			current = ""
+			inEnum = false
+			continue
+		}
+
+		if strings.HasPrefix(line, schemaIndent) && !strings.HasPrefix(line, schemaIndent+" ") && strings.HasSuffix(line, ":") {
+			current = strings.TrimSuffix(strings.TrimSpace(line), ":")
+			inEnum = false
+			continue
+		}
+
+		if current == "" {
+			continue
+		}
+
+		if line == fieldIndent+"enum:" {
+			inEnum = true
+			continue
+		}
+
+		if inEnum {
+			if value, ok := strings.CutPrefix(line, fieldIndent+"- "); ok {
+				enums[current] = append(enums[current], strings.Trim(value, `'"`))
+				continue
+			}
+			inEnum = false
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return enums, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedEnumsMatchSpec(t *testing.T) {
+	spec, err := os.Open("../../../../docs/assets.yaml")
+	if err != nil {
+		t.Fatalf("failed to open spec: %v", err)
+	}
+	defer spec.Close()
+
+	want, err := generate(spec)
+	if err != nil {
+		t.Fatalf("failed to generate enums: %v", err)
+	}
+
+	got, err := os.ReadFile("../enums_gen.go")
+	if err != nil {
+		t.Fatalf("failed to read generated enums: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("enums_gen.go is out of date with docs/assets.yaml; run `go generate ./internal/provider/enums`")
+	}
+}
+
+func TestParseEnums(t *testing.T) {
+	sample := `components:
+  schemas:
+    ColorEnum:
+      type: string
+      enum:
+      - RED
+      - GREEN
+      title: ColorEnum
+    Shape:
+      properties:
+        kind:
+          enum:
+          - NESTED
+      type: object
+paths: {}
+`
+
+	enums, err := parseEnums(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("failed to parse sample spec: %v", err)
+	}
+
+	if got := enums["ColorEnum"]; len(got) != 2 || got[0] != "RED" || got[1] != "GREEN" {
+		t.Fatalf("unexpected ColorEnum values: %v", got)
+	}
+	if _, ok := enums["Shape"]; ok {
+		t.Fatalf("nested enum values must not be attributed to the parent schema")
+	}
+}
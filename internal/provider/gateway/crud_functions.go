@@ -10,6 +10,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 //-----------------------------------------------------------------
@@ -17,6 +19,10 @@ import (
 //-----------------------------------------------------------------
 
 func (r *GatewayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan GatewayResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -43,7 +49,7 @@ func (r *GatewayResource) Create(ctx context.Context, req resource.CreateRequest
 
 	url := fmt.Sprintf("%s/assets/v1/gateway", r.client.HostURL)
 
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
@@ -83,33 +89,29 @@ func (r *GatewayResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	state := GatewayResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		GatewayID:         types.StringValue(apiResp.GatewayID),
-		GroupID:           types.StringPointerValue(apiResp.GroupID),
-		Name:              types.StringValue(apiResp.Name),
-		Description:       types.StringPointerValue(apiResp.Description),
-	}
+	state := buildGatewayState(&apiResp)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, GatewayIdentityModel{GatewayID: state.GatewayID})...)
 }
 
 //-----------------------------------------------------------------
 //         READ
 //-----------------------------------------------------------------
 func (r *GatewayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state GatewayResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, GatewayIdentityModel{GatewayID: state.GatewayID})...)
 
 	url := fmt.Sprintf("%s/assets/v1/gateway/%s", r.client.HostURL, state.GatewayID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
@@ -141,17 +143,7 @@ func (r *GatewayResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	state = GatewayResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		GatewayID:         types.StringValue(apiResp.GatewayID),
-		GroupID:           types.StringPointerValue(apiResp.GroupID),
-		Name:              types.StringValue(apiResp.Name),
-		Description:       types.StringPointerValue(apiResp.Description),
-	}
+	state = buildGatewayState(&apiResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -159,6 +151,10 @@ func (r *GatewayResource) Read(ctx context.Context, req resource.ReadRequest, re
 //         UPDATE
 //-----------------------------------------------------------------
 func (r *GatewayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state GatewayResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -212,7 +208,7 @@ func (r *GatewayResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	url := fmt.Sprintf("%s/assets/v1/gateway/%s", r.client.HostURL, state.GatewayID.ValueString())
 
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
@@ -256,12 +252,17 @@ func (r *GatewayResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, GatewayIdentityModel{GatewayID: state.GatewayID})...)
 }
 
 //-----------------------------------------------------------------
 //         DELETE
 //-----------------------------------------------------------------
 func (r *GatewayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state GatewayResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -269,7 +270,7 @@ func (r *GatewayResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/gateway/%s", r.client.HostURL, state.GatewayID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
@@ -285,3 +286,22 @@ func (r *GatewayResource) Delete(ctx context.Context, req resource.DeleteRequest
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error deleting gateway: %s", err))
 	}
 }
+
+//-----------------------------------------------------------------
+//         HELPER FUNCTIONS
+//-----------------------------------------------------------------
+
+// buildGatewayState maps a gateway API response to its Terraform state.
+func buildGatewayState(apiResp *GatewayAPIResponse) GatewayResourceModel {
+	return GatewayResourceModel{
+		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
+		CreationUserID:    types.StringValue(apiResp.CreationUserID),
+		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
+		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
+		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
+		GatewayID:         types.StringValue(apiResp.GatewayID),
+		GroupID:           types.StringPointerValue(apiResp.GroupID),
+		Name:              types.StringValue(apiResp.Name),
+		Description:       types.StringPointerValue(apiResp.Description),
+	}
+}
@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var _ list.ListResource = &GatewayListResource{}
+var _ list.ListResourceWithConfigure = &GatewayListResource{}
+
+func NewGatewayListResource() list.ListResource {
+	return &GatewayListResource{}
+}
+
+// GatewayListResource enumerates the gateways of the tenant for `terraform query`.
+type GatewayListResource struct {
+	client *clients.Client
+}
+
+// GatewayListConfigModel maps the list block configuration.
+type GatewayListConfigModel struct {
+	GroupID types.String `tfsdk:"group_id"`
+}
+
+func (r *GatewayListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway"
+}
+
+func (r *GatewayListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the gateways of the tenant in the SDA Assets Management Service.",
+		Attributes: map[string]listschema.Attribute{
+			"group_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list gateways that belong to this resource group.",
+			},
+		},
+	}
+}
+
+func (r *GatewayListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *GatewayListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+	if !tenancy.RequireClient(r.client, &diags) {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config GatewayListConfigModel
+	diags = req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	gateways, err := listGateways(ctx, r.client)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Error listing gateways: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, gw := range gateways {
+			if !config.GroupID.IsNull() && (gw.GroupID == nil || *gw.GroupID != config.GroupID.ValueString()) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = gw.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, GatewayIdentityModel{GatewayID: types.StringValue(gw.GatewayID)})...)
+			if req.IncludeResource {
+				state := buildGatewayState(&gw)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listGateways returns every gateway of the tenant.
+func listGateways(ctx context.Context, client *clients.Client) ([]GatewayAPIResponse, error) {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/assets/v1/gateway", client.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []GatewayAPIResponse
+	if err := json.Unmarshal(resBody, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
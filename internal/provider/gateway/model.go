@@ -27,3 +27,8 @@ type GatewayAPIResponse struct {
 	Name              string  `json:"name"`
 	Description       *string `json:"description"`
 }
+
+// GatewayIdentityModel maps the resource identity schema used by import blocks.
+type GatewayIdentityModel struct {
+	GatewayID types.String `tfsdk:"gateway_id"`
+}
@@ -5,15 +5,21 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
 )
 
 var _ resource.Resource = &GatewayResource{}
 var _ resource.ResourceWithImportState = &GatewayResource{}
+var _ resource.ResourceWithIdentity = &GatewayResource{}
+
+// importLookup resolves gateway:<name> and group:<name>/gateway:<name> import IDs.
+var importLookup = importid.Collection{Kind: "gateway", Path: "/assets/v1/gateway", IDField: "gateway_id", Parent: &importid.ResourceGroups, ParentField: "group_id"}
 
 func NewGatewayResource() resource.Resource {
 	return &GatewayResource{}
@@ -89,6 +95,17 @@ func (r *GatewayResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *GatewayResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"gateway_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the gateway.",
+			},
+		},
+	}
+}
+
 func (r *GatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("gateway_id"), req, resp)
+	importid.ImportState(ctx, r.client, importLookup, path.Root("gateway_id"), req, resp)
 }
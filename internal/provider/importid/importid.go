@@ -0,0 +1,198 @@
+// Package importid parses the import IDs accepted by SDA resources. Composite
+// IDs join their parts with "/", and assets of the Assets Management Service
+// can also be imported by name, for example group:<name>/device:<name>.
+package importid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+// Split splits a composite import ID into exactly n parts separated by "/".
+// A part that itself contains "/" must be given percent-encoded as "%2F".
+func Split(id string, n int) ([]string, error) {
+	raw := strings.Split(id, "/")
+	if len(raw) != n {
+		return nil, fmt.Errorf("expected %d parts separated by \"/\", got %d", n, len(raw))
+	}
+
+	parts := make([]string, 0, n)
+	for i, p := range raw {
+		part, err := url.PathUnescape(p)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", i+1, err)
+		}
+		if part == "" {
+			return nil, fmt.Errorf("part %d is empty", i+1)
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// Collection describes an asset list endpoint that lookup-based import IDs are
+// resolved against.
+type Collection struct {
+	// Kind is the prefix used in lookup references, for example "device".
+	Kind string
+	// Path is the list endpoint relative to the host, for example "/assets/v1/device".
+	Path string
+	// IDField is the JSON field holding the asset ID, for example "device_id".
+	IDField string
+	// Parent optionally scopes names, for example resource groups for devices.
+	Parent *Collection
+	// ParentField is the JSON field holding the parent ID, for example "group_id".
+	ParentField string
+}
+
+// ResourceGroups resolves group:<name> references.
+var ResourceGroups = Collection{Kind: "group", Path: "/assets/v1/resource_group", IDField: "group_id"}
+
+// Vaults resolves vault:<name> references.
+var Vaults = Collection{Kind: "vault", Path: "/assets/v1/vault", IDField: "vault_id"}
+
+// Lookup is a parsed lookup reference of the form [<parent kind>:<name>/]<kind>:<name>.
+type Lookup struct {
+	ParentName string
+	Name       string
+}
+
+// ParseLookup parses id as a lookup reference for c. ok is false when id is a
+// plain asset ID. Names may contain "/" when percent-encoded as "%2F".
+func (c Collection) ParseLookup(id string) (lookup Lookup, ok bool, err error) {
+	prefix := c.Kind + ":"
+	parentPrefix := ""
+	if c.Parent != nil {
+		parentPrefix = c.Parent.Kind + ":"
+	}
+
+	if !strings.HasPrefix(id, prefix) && (parentPrefix == "" || !strings.HasPrefix(id, parentPrefix)) {
+		return Lookup{}, false, nil
+	}
+
+	format := prefix + "<name>"
+	if parentPrefix != "" {
+		format = fmt.Sprintf("%s or %s<name>/%s<name>", format, parentPrefix, prefix)
+	}
+
+	segments := strings.Split(id, "/")
+	switch {
+	case len(segments) == 1 && strings.HasPrefix(segments[0], prefix):
+		lookup.Name = strings.TrimPrefix(segments[0], prefix)
+	case len(segments) == 2 && parentPrefix != "" && strings.HasPrefix(segments[0], parentPrefix) && strings.HasPrefix(segments[1], prefix):
+		lookup.ParentName = strings.TrimPrefix(segments[0], parentPrefix)
+		lookup.Name = strings.TrimPrefix(segments[1], prefix)
+		if lookup.ParentName, err = url.PathUnescape(lookup.ParentName); err != nil {
+			return Lookup{}, true, err
+		}
+		if lookup.ParentName == "" {
+			return Lookup{}, true, fmt.Errorf("expected %s, got %q", format, id)
+		}
+	default:
+		return Lookup{}, true, fmt.Errorf("expected %s, got %q", format, id)
+	}
+
+	if lookup.Name, err = url.PathUnescape(lookup.Name); err != nil {
+		return Lookup{}, true, err
+	}
+	if lookup.Name == "" {
+		return Lookup{}, true, fmt.Errorf("expected %s, got %q", format, id)
+	}
+	return lookup, true, nil
+}
+
+// ResolveImportID returns the asset ID referenced by id. Plain IDs are
+// returned unchanged, lookup references are resolved by listing the
+// collection and matching on name.
+func (c Collection) ResolveImportID(ctx context.Context, client *clients.Client, id string) (string, error) {
+	lookup, ok, err := c.ParseLookup(id)
+	if err != nil || !ok {
+		return id, err
+	}
+	if client == nil {
+		return "", fmt.Errorf("importing by name: %w", clients.ErrNotConfigured)
+	}
+
+	parentID := ""
+	if lookup.ParentName != "" {
+		if parentID, err = c.Parent.FindByName(ctx, client, lookup.ParentName, ""); err != nil {
+			return "", err
+		}
+	}
+	return c.FindByName(ctx, client, lookup.Name, parentID)
+}
+
+// FindByName returns the ID of the single asset in c named name. When
+// parentID is set only assets belonging to that parent are considered.
+func (c Collection) FindByName(ctx context.Context, client *clients.Client, name, parentID string) (string, error) {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, client.HostURL+c.Path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return "", fmt.Errorf("listing %ss: %w", c.Kind, err)
+	}
+
+	var items []map[string]any
+	if err := json.Unmarshal(body, &items); err != nil {
+		return "", fmt.Errorf("decoding %s list: %w", c.Kind, err)
+	}
+
+	var matches []string
+	for _, item := range items {
+		if item["name"] != name {
+			continue
+		}
+		if parentID != "" && item[c.ParentField] != parentID {
+			continue
+		}
+		if id, ok := item[c.IDField].(string); ok {
+			matches = append(matches, id)
+		}
+	}
+
+	scope := ""
+	if parentID != "" {
+		scope = fmt.Sprintf(" in %s %s", c.Parent.Kind, parentID)
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q found%s", c.Kind, name, scope)
+	case 1:
+		return matches[0], nil
+	default:
+		hint := ""
+		if c.Parent != nil && parentID == "" {
+			hint = fmt.Sprintf("; qualify the name as %s:<name>/%s:<name>", c.Parent.Kind, c.Kind)
+		}
+		return "", fmt.Errorf("%d %ss named %q found%s%s", len(matches), c.Kind, name, scope, hint)
+	}
+}
+
+// ImportState imports an asset of c by ID or by lookup reference, storing the
+// resolved ID in both the state and the identity attribute at idPath. Imports
+// driven by an identity block are passed through unchanged.
+func ImportState(ctx context.Context, client *clients.Client, c Collection, idPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		id, err := c.ResolveImportID(ctx, client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, idPath, idPath, req, resp)
+}
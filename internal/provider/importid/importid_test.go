@@ -0,0 +1,100 @@
+package importid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestSplit(t *testing.T) {
+	parts, err := Split("user-1/role%2Fadmin", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parts[0] != "user-1" || parts[1] != "role/admin" {
+		t.Fatalf("unexpected parts: %q", parts)
+	}
+
+	for _, id := range []string{"user-1", "user-1/role/admin", "user-1/", "user-1/%zz"} {
+		if _, err := Split(id, 2); err == nil {
+			t.Errorf("Split(%q): expected an error", id)
+		}
+	}
+}
+
+func TestParseLookup(t *testing.T) {
+	devices := Collection{Kind: "device", Parent: &ResourceGroups}
+
+	cases := []struct {
+		id         string
+		want       Lookup
+		wantLookup bool
+		wantErr    bool
+	}{
+		{id: "6f1c0f9e-2b1d-4c1e-9a59-6f8e3b7c2d10"},
+		{id: "device:plc-01", want: Lookup{Name: "plc-01"}, wantLookup: true},
+		{id: "group:line%2F1/device:plc-01", want: Lookup{ParentName: "line/1", Name: "plc-01"}, wantLookup: true},
+		{id: "group:line-1", wantLookup: true, wantErr: true},
+		{id: "device:", wantLookup: true, wantErr: true},
+		{id: "group:a/device:b/device:c", wantLookup: true, wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, ok, err := devices.ParseLookup(c.id)
+		if ok != c.wantLookup || (err != nil) != c.wantErr {
+			t.Errorf("ParseLookup(%q): got lookup=%t err=%v", c.id, ok, err)
+			continue
+		}
+		if !c.wantErr && got != c.want {
+			t.Errorf("ParseLookup(%q): expected %+v, got %+v", c.id, c.want, got)
+		}
+	}
+}
+
+func TestResolveImportID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/assets/v1/resource_group":
+			w.Write([]byte(`[{"group_id": "g1", "name": "line-1"}, {"group_id": "g2", "name": "line-2"}]`))
+		case "/assets/v1/device":
+			w.Write([]byte(`[
+				{"device_id": "d1", "group_id": "g1", "name": "plc-01"},
+				{"device_id": "d2", "group_id": "g2", "name": "plc-01"},
+				{"device_id": "d3", "group_id": "g2", "name": "hmi-01"}
+			]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}
+	devices := Collection{Kind: "device", Path: "/assets/v1/device", IDField: "device_id", Parent: &ResourceGroups, ParentField: "group_id"}
+
+	cases := map[string]string{
+		"d9":                          "d9",
+		"device:hmi-01":               "d3",
+		"group:line-2/device:plc-01":  "d2",
+		"group:line-1/device:plc-01":  "d1",
+		"device:plc-01":               "error: qualify the name",
+		"group:line-3/device:plc-01":  "error: no group named",
+		"group:line-1/device:missing": "error: no device named",
+	}
+
+	for id, want := range cases {
+		got, err := devices.ResolveImportID(context.Background(), client, id)
+		if wantErr, isErr := strings.CutPrefix(want, "error: "); isErr {
+			if err == nil || !strings.Contains(err.Error(), wantErr) {
+				t.Errorf("ResolveImportID(%q): expected error containing %q, got %q, %v", id, wantErr, got, err)
+			}
+			continue
+		}
+		if err != nil || got != want {
+			t.Errorf("ResolveImportID(%q): expected %q, got %q, %v", id, want, got, err)
+		}
+	}
+}
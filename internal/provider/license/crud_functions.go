@@ -15,6 +15,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 const multipartChunkSize = 5 * 1024 * 1024 // 5MB per part
@@ -24,6 +26,10 @@ const multipartChunkSize = 5 * 1024 * 1024 // 5MB per part
 //-----------------------------------------------------------------
 
 func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan LicenseResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -147,7 +153,7 @@ func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/license", r.client.HostURL)
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
@@ -176,13 +182,13 @@ func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest
 
 		completeParts = make([]S3MultipartCompleteInfo, numParts)
 		for i, uploadURL := range apiResp.UploadURLs {
-			partReq, err := http.NewRequest(http.MethodPut, uploadURL.UploadURL, bytes.NewReader(chunks[i]))
+			partReq, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL.UploadURL, bytes.NewReader(chunks[i]))
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating part upload request: %s", err))
 				return
 			}
 
-			partResp, err := http.DefaultClient.Do(partReq)
+			partResp, err := r.client.Upload(partReq)
 			if err != nil {
 				resp.Diagnostics.AddError("Upload Error", fmt.Sprintf("Error uploading part %d: %s", i+1, err))
 				return
@@ -214,7 +220,7 @@ func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 
 		completeURL := fmt.Sprintf("%s/assets/v1/license/%s/file?upload_id=%s", r.client.HostURL, apiResp.LicenseID, apiResp.UploadID)
-		completeReq, err := http.NewRequest(http.MethodPost, completeURL, bytes.NewReader(completeBody))
+		completeReq, err := http.NewRequestWithContext(ctx, http.MethodPost, completeURL, bytes.NewReader(completeBody))
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating complete request: %s", err))
 			return
@@ -256,20 +262,26 @@ func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, LicenseIdentityModel{LicenseID: state.LicenseID})...)
 }
 
 //-----------------------------------------------------------------
 //         READ
 //-----------------------------------------------------------------
 func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state LicenseResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, LicenseIdentityModel{LicenseID: state.LicenseID})...)
 
 	url := fmt.Sprintf("%s/assets/v1/license/%s", r.client.HostURL, state.LicenseID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
@@ -321,6 +333,10 @@ func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, re
 //         UPDATE
 //-----------------------------------------------------------------
 func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state LicenseResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -434,7 +450,7 @@ func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/license/%s", r.client.HostURL, state.LicenseID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
@@ -477,12 +493,17 @@ func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest
 	state.LicenseServer = types.StringPointerValue(apiResp.LicenseServer)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, LicenseIdentityModel{LicenseID: state.LicenseID})...)
 }
 
 //-----------------------------------------------------------------
 //         DELETE
 //-----------------------------------------------------------------
 func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state LicenseResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -490,7 +511,7 @@ func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/license/%s", r.client.HostURL, state.LicenseID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
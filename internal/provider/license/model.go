@@ -96,4 +96,9 @@ type S3MultipartCompleteInfo struct {
 type CompleteMultipartUploadRequest struct {
 	Parts    []S3MultipartCompleteInfo `json:"parts"`
 	FileName string                    `json:"file_name"`
-}
\ No newline at end of file
+}
+
+// LicenseIdentityModel maps the resource identity schema used by import blocks.
+type LicenseIdentityModel struct {
+	LicenseID types.String `tfsdk:"license_id"`
+}
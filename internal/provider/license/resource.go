@@ -5,18 +5,26 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
 )
 
 var _ resource.Resource = &LicenseResource{}
 var _ resource.ResourceWithImportState = &LicenseResource{}
+var _ resource.ResourceWithIdentity = &LicenseResource{}
+
+// importLookup resolves license:<name> and group:<name>/license:<name> import IDs.
+var importLookup = importid.Collection{Kind: "license", Path: "/assets/v1/license", IDField: "license_id", Parent: &importid.ResourceGroups, ParentField: "group_id"}
 
 func NewLicenseResource() resource.Resource {
 	return &LicenseResource{}
@@ -74,7 +82,10 @@ func (r *LicenseResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				Description: "Type of license (COOPERATE, FLOATING, SINGLE, UPGRADE, TRIAL).",
-				Default:     stringdefault.StaticString("FLOATING"),
+				Validators: []validator.String{
+					enums.OneOf(enums.LicenseTypeEnum),
+				},
+				Default: stringdefault.StaticString("FLOATING"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -83,7 +94,10 @@ func (r *LicenseResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				Description: "Status of the license (REQUESTED, ACTIVE, UPLOADED, EXPIRED, INVALID).",
-				Default:     stringdefault.StaticString("REQUESTED"),
+				Validators: []validator.String{
+					enums.OneOf(enums.LicenseStatusEnum),
+				},
+				Default: stringdefault.StaticString("REQUESTED"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -214,6 +228,17 @@ func (r *LicenseResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *LicenseResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"license_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the license.",
+			},
+		},
+	}
+}
+
 func (r *LicenseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("license_id"), req, resp)
-}
\ No newline at end of file
+	importid.ImportState(ctx, r.client, importLookup, path.Root("license_id"), req, resp)
+}
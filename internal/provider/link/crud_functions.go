@@ -6,10 +6,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/metadata"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 //-----------------------------------------------------------------
@@ -17,6 +24,10 @@ import (
 //-----------------------------------------------------------------
 
 func (r *LinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan LinkResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -26,11 +37,13 @@ func (r *LinkResource) Create(ctx context.Context, req resource.CreateRequest, r
 	payload := map[string]interface{}{}
 
 	// Include metadata if provided
-	if !plan.MetaData.IsUnknown() && !plan.MetaData.IsNull() {
-		var metaData AssetLinkMetaData
-		if err := json.Unmarshal([]byte(plan.MetaData.ValueString()), &metaData); err == nil {
-			payload["meta_data"] = metaData
-		}
+	metaData, diags := plannedMetaData(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if metaData != nil {
+		payload["meta_data"] = metaData
 	}
 
 	body, err := json.Marshal(payload)
@@ -39,15 +52,9 @@ func (r *LinkResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	url := fmt.Sprintf("%s/assets/v1/link/%s/%s/%s/%s",
-		r.client.HostURL,
-		plan.SourceType.ValueString(),
-		plan.SourceID.ValueString(),
-		plan.DestinationType.ValueString(),
-		plan.DestinationID.ValueString(),
-	)
+	url := linkURL(r.client.HostURL, plan)
 
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
@@ -79,52 +86,33 @@ func (r *LinkResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	state := LinkResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		SourceID:          types.StringValue(apiResp.SourceID),
-		SourceType:        types.StringValue(apiResp.SourceType),
-		DestinationID:     types.StringValue(apiResp.DestinationID),
-		DestinationType:   types.StringValue(apiResp.DestinationType),
-	}
-
-	// Build metadata JSON string
-	if apiResp.MetaData != nil {
-		metaDataJSON, err := json.Marshal(apiResp.MetaData)
-		if err == nil {
-			state.MetaData = types.StringValue(string(metaDataJSON))
-		} else {
-			state.MetaData = types.StringNull()
-		}
-	} else {
-		state.MetaData = types.StringNull()
+	state := buildLinkState(&apiResp, !plan.MetaDataMap.IsNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, linkIdentity(state))...)
 }
 
 //-----------------------------------------------------------------
 //         READ
 //-----------------------------------------------------------------
 func (r *LinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state LinkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, linkIdentity(state))...)
 
-	url := fmt.Sprintf("%s/assets/v1/link/%s/%s/%s/%s",
-		r.client.HostURL,
-		state.SourceType.ValueString(),
-		state.SourceID.ValueString(),
-		state.DestinationType.ValueString(),
-		state.DestinationID.ValueString(),
-	)
+	url := linkURL(r.client.HostURL, state)
 
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
@@ -159,28 +147,9 @@ func (r *LinkResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	state = LinkResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		SourceID:          types.StringValue(apiResp.SourceID),
-		SourceType:        types.StringValue(apiResp.SourceType),
-		DestinationID:     types.StringValue(apiResp.DestinationID),
-		DestinationType:   types.StringValue(apiResp.DestinationType),
-	}
-
-	// Build metadata JSON string
-	if apiResp.MetaData != nil {
-		metaDataJSON, err := json.Marshal(apiResp.MetaData)
-		if err == nil {
-			state.MetaData = types.StringValue(string(metaDataJSON))
-		} else {
-			state.MetaData = types.StringNull()
-		}
-	} else {
-		state.MetaData = types.StringNull()
+	state = buildLinkState(&apiResp, !state.MetaDataMap.IsNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -190,6 +159,10 @@ func (r *LinkResource) Read(ctx context.Context, req resource.ReadRequest, resp
 //         UPDATE
 //-----------------------------------------------------------------
 func (r *LinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state LinkResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -202,15 +175,13 @@ func (r *LinkResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Include metadata if changed
-	if !plan.MetaData.Equal(state.MetaData) {
-		if plan.MetaData.IsNull() {
-			payload["meta_data"] = nil
-		} else {
-			var metaData AssetLinkMetaData
-			if err := json.Unmarshal([]byte(plan.MetaData.ValueString()), &metaData); err == nil {
-				payload["meta_data"] = metaData
-			}
+	if !plan.MetaData.Equal(state.MetaData) || !plan.MetaDataMap.Equal(state.MetaDataMap) {
+		metaData, diags := plannedMetaData(plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		payload["meta_data"] = metaData
 	}
 
 	body, err := json.Marshal(payload)
@@ -219,15 +190,9 @@ func (r *LinkResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	url := fmt.Sprintf("%s/assets/v1/link/%s/%s/%s/%s",
-		r.client.HostURL,
-		state.SourceType.ValueString(),
-		state.SourceID.ValueString(),
-		state.DestinationType.ValueString(),
-		state.DestinationID.ValueString(),
-	)
+	url := linkURL(r.client.HostURL, state)
 
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
@@ -247,56 +212,36 @@ func (r *LinkResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Preserve null in state when user removed metadata
-	if plan.MetaData.IsNull() {
+	if plan.MetaData.IsNull() && plan.MetaDataMap.IsNull() {
 		apiResp.MetaData = nil
 	}
 
-	state = LinkResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		SourceID:          types.StringValue(apiResp.SourceID),
-		SourceType:        types.StringValue(apiResp.SourceType),
-		DestinationID:     types.StringValue(apiResp.DestinationID),
-		DestinationType:   types.StringValue(apiResp.DestinationType),
-	}
-
-	// Build metadata JSON string
-	if apiResp.MetaData != nil {
-		metaDataJSON, err := json.Marshal(apiResp.MetaData)
-		if err == nil {
-			state.MetaData = types.StringValue(string(metaDataJSON))
-		} else {
-			state.MetaData = types.StringNull()
-		}
-	} else {
-		state.MetaData = types.StringNull()
+	state = buildLinkState(&apiResp, !plan.MetaDataMap.IsNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, linkIdentity(state))...)
 }
 
 //-----------------------------------------------------------------
 //         DELETE
 //-----------------------------------------------------------------
 func (r *LinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state LinkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	url := fmt.Sprintf("%s/assets/v1/link/%s/%s/%s/%s",
-		r.client.HostURL,
-		state.SourceType.ValueString(),
-		state.SourceID.ValueString(),
-		state.DestinationType.ValueString(),
-		state.DestinationID.ValueString(),
-	)
+	url := linkURL(r.client.HostURL, state)
 
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
@@ -312,3 +257,100 @@ func (r *LinkResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error deleting link: %s", err))
 	}
 }
+
+//-----------------------------------------------------------------
+//         HELPER FUNCTIONS
+//-----------------------------------------------------------------
+
+// plannedMetaData decodes whichever of meta_data or meta_data_map is
+// configured into the link meta data accepted by the API. Unknown keys are
+// rejected instead of being silently dropped.
+func plannedMetaData(plan LinkResourceModel) (*AssetLinkMetaData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var raw []byte
+	attrPath := path.Root("meta_data")
+	switch {
+	case !plan.MetaDataMap.IsNull() && !plan.MetaDataMap.IsUnknown():
+		attrPath = path.Root("meta_data_map")
+		value, err := metadata.DynamicToNative(plan.MetaDataMap)
+		if err == nil {
+			raw, err = json.Marshal(value)
+		}
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Invalid Meta Data", fmt.Sprintf("meta_data_map must be an object with known values: %s", err))
+			return nil, diags
+		}
+	case !plan.MetaData.IsNull() && !plan.MetaData.IsUnknown():
+		raw = []byte(plan.MetaData.ValueString())
+	default:
+		return nil, diags
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	var metaData AssetLinkMetaData
+	if err := dec.Decode(&metaData); err != nil {
+		diags.AddAttributeError(attrPath, "Invalid Meta Data", fmt.Sprintf("Link meta data does not match the asset link meta data schema: %s", err))
+		return nil, diags
+	}
+
+	return &metaData, diags
+}
+
+func buildLinkState(apiResp *LinkAPIResponse, metaDataAsMap bool, diags *diag.Diagnostics) LinkResourceModel {
+	state := LinkResourceModel{
+		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
+		CreationUserID:    types.StringValue(apiResp.CreationUserID),
+		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
+		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
+		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
+		SourceID:          types.StringValue(apiResp.SourceID),
+		SourceType:        types.StringValue(apiResp.SourceType),
+		DestinationID:     types.StringValue(apiResp.DestinationID),
+		DestinationType:   types.StringValue(apiResp.DestinationType),
+		MetaData:          jsontypes.NewNormalizedNull(),
+		MetaDataMap:       types.DynamicNull(),
+	}
+
+	// Build metadata in the representation chosen in the configuration
+	if apiResp.MetaData != nil {
+		metaDataJSON, err := json.Marshal(apiResp.MetaData)
+		if err != nil {
+			diags.AddError("Decode Error", fmt.Sprintf("Error encoding link meta_data: %s", err))
+		} else if metaDataAsMap {
+			metaDataMap, err := metadata.DynamicFromJSON(metaDataJSON)
+			if err != nil {
+				diags.AddError("Decode Error", fmt.Sprintf("Error converting link meta_data: %s", err))
+			}
+			state.MetaDataMap = metaDataMap
+		} else {
+			state.MetaData = jsontypes.NewNormalizedValue(string(metaDataJSON))
+		}
+	}
+
+	return state
+}
+
+// linkURL returns the API URL of the link between two assets. IDs are path
+// escaped so that IDs containing "/" address the right link.
+func linkURL(host string, m LinkResourceModel) string {
+	return fmt.Sprintf("%s/assets/v1/link/%s/%s/%s/%s",
+		host,
+		url.PathEscape(m.SourceType.ValueString()),
+		url.PathEscape(m.SourceID.ValueString()),
+		url.PathEscape(m.DestinationType.ValueString()),
+		url.PathEscape(m.DestinationID.ValueString()),
+	)
+}
+
+// linkIdentity returns the resource identity of the link.
+func linkIdentity(m LinkResourceModel) LinkIdentityModel {
+	return LinkIdentityModel{
+		SourceType:      m.SourceType,
+		SourceID:        m.SourceID,
+		DestinationType: m.DestinationType,
+		DestinationID:   m.DestinationID,
+	}
+}
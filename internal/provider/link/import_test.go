@@ -0,0 +1,33 @@
+package link
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	cases := []struct {
+		id      string
+		want    []string
+		wantErr bool
+	}{
+		{id: "DEVICE/d1/DOCUMENT/doc1", want: []string{"DEVICE", "d1", "DOCUMENT", "doc1"}},
+		{id: "DEVICE/plant/a/d1/PROJECT/p/1", want: []string{"DEVICE", "plant/a/d1", "PROJECT", "p/1"}},
+		{id: "DEVICE/a%2FDEVICE%2Fb/TAG/t1", want: []string{"DEVICE", "a/DEVICE/b", "TAG", "t1"}},
+		{id: "DEVICE/a/DEVICE/b/TAG/t1", wantErr: true},
+		{id: "DEVICE/d1/DOCUMENT", wantErr: true},
+		{id: "MACHINE/d1/DOCUMENT/doc1", wantErr: true},
+		{id: "DEVICE/d1/doc1/x", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := parseImportID(c.id)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseImportID(%q): unexpected error state: %v", c.id, err)
+			continue
+		}
+		if !c.wantErr && !slices.Equal(got, c.want) {
+			t.Errorf("parseImportID(%q): expected %q, got %q", c.id, c.want, got)
+		}
+	}
+}
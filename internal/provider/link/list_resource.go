@@ -0,0 +1,150 @@
+package link
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var _ list.ListResource = &LinkListResource{}
+var _ list.ListResourceWithConfigure = &LinkListResource{}
+
+func NewLinkListResource() list.ListResource {
+	return &LinkListResource{}
+}
+
+// LinkListResource enumerates the links starting at an asset for `terraform query`.
+type LinkListResource struct {
+	client *clients.Client
+}
+
+// LinkListConfigModel maps the list block configuration.
+type LinkListConfigModel struct {
+	SourceType      types.String `tfsdk:"source_type"`
+	SourceID        types.String `tfsdk:"source_id"`
+	DestinationType types.String `tfsdk:"destination_type"`
+}
+
+func (r *LinkListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_link"
+}
+
+func (r *LinkListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the asset links starting at an asset in the SDA Assets Management Service.",
+		Attributes: map[string]listschema.Attribute{
+			"source_type": listschema.StringAttribute{
+				Required:    true,
+				Description: "Type of the asset the links start at.",
+				Validators: []validator.String{
+					enums.OneOf(enums.AssetTypeEnum),
+				},
+			},
+			"source_id": listschema.StringAttribute{
+				Required:    true,
+				Description: "ID of the asset the links start at.",
+			},
+			"destination_type": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list links to assets of this type.",
+				Validators: []validator.String{
+					enums.OneOf(enums.AssetTypeEnum),
+				},
+			},
+		},
+	}
+}
+
+func (r *LinkListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *LinkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+	if !tenancy.RequireClient(r.client, &diags) {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config LinkListConfigModel
+	diags = req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	links, err := listLinks(ctx, r.client, config)
+	if err != nil {
+		diags.AddError(
+			"API Error",
+			fmt.Sprintf("Error listing links from %s/%s: %s", config.SourceType.ValueString(), config.SourceID.ValueString(), err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, l := range links {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			state := buildLinkState(&l, false, &result.Diagnostics)
+			result.DisplayName = fmt.Sprintf("%s/%s -> %s/%s", l.SourceType, l.SourceID, l.DestinationType, l.DestinationID)
+			result.Diagnostics.Append(result.Identity.Set(ctx, linkIdentity(state))...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listLinks returns the links starting at the configured source asset.
+func listLinks(ctx context.Context, client *clients.Client, config LinkListConfigModel) ([]LinkAPIResponse, error) {
+	u := fmt.Sprintf("%s/assets/v1/link/%s/%s",
+		client.HostURL,
+		url.PathEscape(config.SourceType.ValueString()),
+		url.PathEscape(config.SourceID.ValueString()),
+	)
+	if !config.DestinationType.IsNull() {
+		u += "?" + url.Values{"destination_type": {config.DestinationType.ValueString()}}.Encode()
+	}
+
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []LinkAPIResponse
+	if err := json.Unmarshal(resBody, &links); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
@@ -1,20 +1,22 @@
 package link
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type LinkResourceModel struct {
-	SourceID          types.String `tfsdk:"source_id"`
-	SourceType        types.String `tfsdk:"source_type"`
-	DestinationID     types.String `tfsdk:"destination_id"`
-	DestinationType   types.String `tfsdk:"destination_type"`
-	MetaData          types.String `tfsdk:"meta_data"`
-	ObjectVersion     types.Int64  `tfsdk:"object_version"`
-	CreationUserID    types.String `tfsdk:"creation_user_id"`
-	UpdateUserID      types.String `tfsdk:"update_user_id"`
-	CreationTimestamp types.String `tfsdk:"creation_timestamp"`
-	UpdateTimestamp   types.String `tfsdk:"update_timestamp"`
+	SourceID          types.String         `tfsdk:"source_id"`
+	SourceType        types.String         `tfsdk:"source_type"`
+	DestinationID     types.String         `tfsdk:"destination_id"`
+	DestinationType   types.String         `tfsdk:"destination_type"`
+	MetaData          jsontypes.Normalized `tfsdk:"meta_data"`
+	MetaDataMap       types.Dynamic        `tfsdk:"meta_data_map"`
+	ObjectVersion     types.Int64          `tfsdk:"object_version"`
+	CreationUserID    types.String         `tfsdk:"creation_user_id"`
+	UpdateUserID      types.String         `tfsdk:"update_user_id"`
+	CreationTimestamp types.String         `tfsdk:"creation_timestamp"`
+	UpdateTimestamp   types.String         `tfsdk:"update_timestamp"`
 }
 
 type AssetLinkMetaData struct {
@@ -58,3 +60,11 @@ type LinkAPIResponse struct {
 	DestinationType   string             `json:"destination_type"`
 	MetaData          *AssetLinkMetaData `json:"meta_data,omitempty"`
 }
+
+// LinkIdentityModel maps the resource identity schema used by import blocks.
+type LinkIdentityModel struct {
+	SourceType      types.String `tfsdk:"source_type"`
+	SourceID        types.String `tfsdk:"source_id"`
+	DestinationType types.String `tfsdk:"destination_type"`
+	DestinationID   types.String `tfsdk:"destination_id"`
+}
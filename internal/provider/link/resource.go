@@ -3,18 +3,30 @@ package link
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/validators"
 )
 
 var _ resource.Resource = &LinkResource{}
 var _ resource.ResourceWithImportState = &LinkResource{}
+var _ resource.ResourceWithIdentity = &LinkResource{}
 
 func NewLinkResource() resource.Resource {
 	return &LinkResource{}
@@ -42,6 +54,9 @@ func (r *LinkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"source_type": schema.StringAttribute{
 				Required:    true,
 				Description: "The source type of the asset link (DEVICE, DOCUMENT, GATEWAY, LICENSE, PROJECT, TAG, VAULT, SECRET, etc.).",
+				Validators: []validator.String{
+					enums.OneOf(enums.AssetTypeEnum),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -56,17 +71,32 @@ func (r *LinkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"destination_type": schema.StringAttribute{
 				Required:    true,
 				Description: "The destination type of the asset link (DEVICE, DOCUMENT, GATEWAY, LICENSE, PROJECT, TAG, VAULT, SECRET, etc.).",
+				Validators: []validator.String{
+					enums.OneOf(enums.AssetTypeEnum),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"meta_data": schema.StringAttribute{
 				Optional:    true,
-				Description: "Metadata for the asset link in JSON format. The structure depends on the asset types being linked.",
+				CustomType:  jsontypes.NormalizedType{},
+				Description: "Metadata for the asset link as a JSON object. The structure depends on the asset types being linked. Key order and whitespace are ignored when comparing. Conflicts with meta_data_map.",
+				Validators: []validator.String{
+					validators.JSONObject(),
+					stringvalidator.ConflictsWith(path.MatchRoot("meta_data_map")),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"meta_data_map": schema.DynamicAttribute{
+				Optional:    true,
+				Description: "Metadata for the asset link as a Terraform object, as an alternative to the JSON encoded meta_data. Conflicts with meta_data.",
+				Validators: []validator.Dynamic{
+					dynamicvalidator.ConflictsWith(path.MatchRoot("meta_data")),
+				},
+			},
 			"object_version": schema.Int64Attribute{
 				Computed:    true,
 				Description: "Version number of the object, used for optimistic locking and change tracking.",
@@ -98,38 +128,104 @@ func (r *LinkResource) Configure(_ context.Context, req resource.ConfigureReques
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *LinkResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"source_type": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Type of the source asset.",
+			},
+			"source_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier of the source asset.",
+			},
+			"destination_type": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Type of the destination asset.",
+			},
+			"destination_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier of the destination asset.",
+			},
+		},
+	}
+}
+
 func (r *LinkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: source_type/source_id/destination_type/destination_id
-	// We'll parse this in a custom way
-	idParts := parseImportID(req.ID)
-	if len(idParts) != 4 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID format: source_type/source_id/destination_type/destination_id, got: %s", req.ID),
-		)
+	var identity LinkIdentityModel
+
+	if req.ID != "" {
+		// Import format: source_type/source_id/destination_type/destination_id
+		idParts, err := parseImportID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected import ID format: source_type/source_id/destination_type/destination_id, got: %s (%s)", req.ID, err),
+			)
+			return
+		}
+		identity = LinkIdentityModel{
+			SourceType:      types.StringValue(idParts[0]),
+			SourceID:        types.StringValue(idParts[1]),
+			DestinationType: types.StringValue(idParts[2]),
+			DestinationID:   types.StringValue(idParts[3]),
+		}
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_type"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), idParts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_type"), idParts[2])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_id"), idParts[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_type"), identity.SourceType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), identity.SourceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_type"), identity.DestinationType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_id"), identity.DestinationID)...)
 }
 
-// Helper function to parse import ID
-func parseImportID(id string) []string {
-	parts := []string{}
-	current := ""
-	for _, char := range id {
-		if char == '/' {
-			parts = append(parts, current)
-			current = ""
-		} else {
-			current += string(char)
+// parseImportID splits a link import ID into its four parts. Asset IDs may
+// contain "/", so the destination type is found by looking for the single
+// asset type segment between the two IDs. Parts may be percent-encoded, which
+// resolves IDs that themselves contain "/<ASSET_TYPE>/".
+func parseImportID(id string) ([]string, error) {
+	segments := strings.Split(id, "/")
+	if len(segments) < 4 {
+		return nil, fmt.Errorf("expected 4 parts separated by \"/\", got %d", len(segments))
+	}
+	if !slices.Contains(enums.AssetTypeEnum, segments[0]) {
+		return nil, fmt.Errorf("unknown source type %q", segments[0])
+	}
+
+	split := -1
+	for i := 2; i <= len(segments)-2; i++ {
+		if !slices.Contains(enums.AssetTypeEnum, segments[i]) {
+			continue
 		}
+		if split != -1 {
+			return nil, fmt.Errorf("destination type is ambiguous, encode \"/\" inside IDs as %%2F")
+		}
+		split = i
+	}
+	if split == -1 {
+		return nil, fmt.Errorf("no destination type found")
+	}
+
+	parts := []string{
+		segments[0],
+		strings.Join(segments[1:split], "/"),
+		segments[split],
+		strings.Join(segments[split+1:], "/"),
 	}
-	if current != "" {
-		parts = append(parts, current)
+	for i, part := range parts {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, err
+		}
+		if unescaped == "" {
+			return nil, fmt.Errorf("part %d is empty", i+1)
+		}
+		parts[i] = unescaped
 	}
-	return parts
+	return parts, nil
 }
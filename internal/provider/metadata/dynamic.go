@@ -0,0 +1,159 @@
+// Package metadata converts free-form asset meta data between its JSON API
+// representation and the Terraform dynamic value used by meta_data_map
+// attributes.
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// DynamicFromJSON converts a JSON document into a dynamic value. Objects
+// become Terraform objects, arrays become tuples and numbers keep their full
+// precision.
+func DynamicFromJSON(raw []byte) (types.Dynamic, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return types.DynamicNull(), err
+	}
+
+	value, err := fromNative(v)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+
+	return types.DynamicValue(value), nil
+}
+
+// DynamicToNative converts a dynamic value into plain Go values that encode
+// to the equivalent JSON document. Unknown values are rejected.
+func DynamicToNative(v types.Dynamic) (any, error) {
+	if v.IsUnknown() || v.IsUnderlyingValueUnknown() {
+		return nil, fmt.Errorf("value is not yet known")
+	}
+	if v.IsNull() || v.IsUnderlyingValueNull() {
+		return nil, nil
+	}
+	return toNative(v.UnderlyingValue())
+}
+
+func fromNative(v any) (attr.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return types.DynamicNull(), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case json.Number:
+		f, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(f), nil
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, e := range v {
+			value, err := fromNative(e)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, value.Type(context.Background()))
+			elems = append(elems, value)
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("building tuple: %v", diags)
+		}
+		return tuple, nil
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for k, e := range v {
+			value, err := fromNative(e)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[k] = value.Type(context.Background())
+			attrs[k] = value
+		}
+		obj, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("building object: %v", diags)
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value of type %T", v)
+	}
+}
+
+func toNative(v attr.Value) (any, error) {
+	if v.IsUnknown() {
+		return nil, fmt.Errorf("value is not yet known")
+	}
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	switch v := v.(type) {
+	case basetypes.DynamicValue:
+		return DynamicToNative(v)
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.NumberValue:
+		return json.Number(v.ValueBigFloat().Text('g', -1)), nil
+	case basetypes.Int64Value:
+		return v.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.ObjectValue:
+		return mapToNative(v.Attributes())
+	case basetypes.MapValue:
+		return mapToNative(v.Elements())
+	case basetypes.TupleValue:
+		return listToNative(v.Elements())
+	case basetypes.ListValue:
+		return listToNative(v.Elements())
+	case basetypes.SetValue:
+		return listToNative(v.Elements())
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", v)
+	}
+}
+
+func mapToNative(elems map[string]attr.Value) (map[string]any, error) {
+	out := make(map[string]any, len(elems))
+	for k, e := range elems {
+		value, err := toNative(e)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		out[k] = value
+	}
+	return out, nil
+}
+
+func listToNative(elems []attr.Value) ([]any, error) {
+	out := make([]any, 0, len(elems))
+	for i, e := range elems {
+		value, err := toNative(e)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
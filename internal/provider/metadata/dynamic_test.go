@@ -0,0 +1,53 @@
+package metadata
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDynamicRoundTrip(t *testing.T) {
+	sample := `{"location": "hall 3", "rack": 2, "ratio": 0.25, "active": true, "tags": ["a", 1], "owner": null, "nested": {"slot": 7}}`
+
+	value, err := DynamicFromJSON([]byte(sample))
+	if err != nil {
+		t.Fatalf("failed to convert JSON to dynamic value: %v", err)
+	}
+
+	native, err := DynamicToNative(value)
+	if err != nil {
+		t.Fatalf("failed to convert dynamic value to native: %v", err)
+	}
+
+	got, err := json.Marshal(native)
+	if err != nil {
+		t.Fatalf("failed to marshal native value: %v", err)
+	}
+
+	var want, roundTripped any
+	if err := json.Unmarshal([]byte(sample), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(got, &roundTripped); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(want, roundTripped) {
+		t.Fatalf("round trip mismatch:\nwant: %v\ngot:  %v", want, roundTripped)
+	}
+}
+
+func TestDynamicFromJSONIsStable(t *testing.T) {
+	a, err := DynamicFromJSON([]byte(`{"b": 1, "a": {"y": "2", "x": [true]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := DynamicFromJSON([]byte(`{"a": {"x": [true], "y": "2"}, "b": 1.0}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !a.Equal(b) {
+		t.Fatalf("expected key order and number formatting to be ignored: %s vs %s", a, b)
+	}
+}
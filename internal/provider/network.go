@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+// transportConfig returns the proxy and TLS settings of config, falling back
+// to their environment variables or the profile through getenv.
+func transportConfig(config SDAProviderModel, getenv func(string) string) (clients.TransportConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var cfg clients.TransportConfig
+
+	cfg.ProxyURL = authSetting{envVar: "SDA_PROXY_URL", config: config.ProxyURL}.value(getenv)
+	caCertFile := authSetting{envVar: "SDA_CA_CERT_FILE", config: config.CACertFile}.value(getenv)
+	caCertPEM := authSetting{envVar: "SDA_CA_CERT_PEM", config: config.CACertPEM}.value(getenv)
+	clientCert := authSetting{envVar: "SDA_CLIENT_CERT", config: config.ClientCert}.value(getenv)
+	clientKey := authSetting{envVar: "SDA_CLIENT_KEY", config: config.ClientKey}.value(getenv)
+
+	switch {
+	case caCertFile != "" && caCertPEM != "":
+		diags.AddAttributeError(path.Root("ca_cert_file"), "Conflicting SDA CA Certificates",
+			"Only one of ca_cert_file (SDA_CA_CERT_FILE) and ca_cert_pem (SDA_CA_CERT_PEM) can be set.")
+	case caCertFile != "":
+		b, err := os.ReadFile(caCertFile)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unreadable SDA CA Certificate File",
+				fmt.Sprintf("The provider cannot read the CA certificate file %s: %s", caCertFile, err))
+		}
+		cfg.CACertPEM = b
+	case caCertPEM != "":
+		cfg.CACertPEM = []byte(caCertPEM)
+	}
+
+	if (clientCert == "") != (clientKey == "") {
+		diags.AddAttributeError(path.Root("client_cert"), "Incomplete SDA Client Certificate",
+			"Mutual TLS requires both client_cert (SDA_CLIENT_CERT) and client_key (SDA_CLIENT_KEY).")
+	} else if clientCert != "" {
+		var err error
+		if cfg.ClientCertPEM, err = readPEM(clientCert); err != nil {
+			diags.AddAttributeError(path.Root("client_cert"), "Unreadable SDA Client Certificate",
+				fmt.Sprintf("The provider cannot read the client certificate: %s", err))
+		}
+		if cfg.ClientKeyPEM, err = readPEM(clientKey); err != nil {
+			diags.AddAttributeError(path.Root("client_key"), "Unreadable SDA Client Key",
+				fmt.Sprintf("The provider cannot read the client key: %s", err))
+		}
+	}
+
+	if !config.InsecureSkipVerify.IsNull() {
+		cfg.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	} else if v := getenv("SDA_INSECURE_SKIP_VERIFY"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			diags.AddAttributeError(path.Root("insecure_skip_verify"), "Invalid SDA_INSECURE_SKIP_VERIFY",
+				fmt.Sprintf("SDA_INSECURE_SKIP_VERIFY must be true or false, got %q.", v))
+		}
+		cfg.InsecureSkipVerify = insecure
+	}
+	if cfg.InsecureSkipVerify {
+		diags.AddAttributeWarning(path.Root("insecure_skip_verify"), "SDA TLS Verification Disabled",
+			"The provider does not verify the certificate of the SDA API and of upload URLs. "+
+				"Only use insecure_skip_verify against development or staging systems; configure ca_cert_file or ca_cert_pem instead where possible.")
+	}
+
+	return cfg, diags
+}
+
+// readPEM returns value when it is PEM encoded, and otherwise the content of
+// the file it names.
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// requestLimits returns the client-side request limits of config, falling
+// back to their environment variables or the profile through getenv.
+func requestLimits(config SDAProviderModel, getenv func(string) string) (clients.Limits, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var limits clients.Limits
+
+	if !config.RequestsPerSecond.IsNull() {
+		limits.RequestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	} else if v := getenv("SDA_REQUESTS_PER_SECOND"); v != "" {
+		rps, err := strconv.ParseFloat(v, 64)
+		if err != nil || rps < 0 {
+			diags.AddAttributeError(path.Root("requests_per_second"), "Invalid SDA_REQUESTS_PER_SECOND",
+				fmt.Sprintf("SDA_REQUESTS_PER_SECOND must be a number of at least 0, got %q.", v))
+		}
+		limits.RequestsPerSecond = rps
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		limits.MaxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	} else if v := getenv("SDA_MAX_CONCURRENT_REQUESTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			diags.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid SDA_MAX_CONCURRENT_REQUESTS",
+				fmt.Sprintf("SDA_MAX_CONCURRENT_REQUESTS must be a whole number of at least 0, got %q.", v))
+		}
+		limits.MaxConcurrentRequests = n
+	}
+
+	return limits, diags
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTransportConfig(t *testing.T) {
+	const caPEM = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(caPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	null := SDAProviderModel{
+		ProxyURL: types.StringNull(), CACertFile: types.StringNull(), CACertPEM: types.StringNull(),
+		ClientCert: types.StringNull(), ClientKey: types.StringNull(), InsecureSkipVerify: types.BoolNull(),
+	}
+	with := func(f func(*SDAProviderModel)) SDAProviderModel {
+		c := null
+		f(&c)
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		config   SDAProviderModel
+		env      map[string]string
+		wantCA   string
+		insecure bool
+		warnings int
+		wantErr  bool
+	}{
+		{name: "none", config: null},
+		{name: "ca file from env", config: null, env: map[string]string{"SDA_CA_CERT_FILE": caFile}, wantCA: caPEM},
+		{name: "ca pem in config", config: with(func(c *SDAProviderModel) { c.CACertPEM = types.StringValue(caPEM) }), wantCA: caPEM},
+		{name: "conflicting ca", config: with(func(c *SDAProviderModel) { c.CACertPEM = types.StringValue(caPEM) }), env: map[string]string{"SDA_CA_CERT_FILE": caFile}, wantErr: true},
+		{name: "missing ca file", config: with(func(c *SDAProviderModel) { c.CACertFile = types.StringValue(caFile + ".missing") }), wantErr: true},
+		{name: "client cert without key", config: with(func(c *SDAProviderModel) { c.ClientCert = types.StringValue(caPEM) }), wantErr: true},
+		{name: "insecure from env", config: null, env: map[string]string{"SDA_INSECURE_SKIP_VERIFY": "true"}, insecure: true, warnings: 1},
+		{name: "config takes precedence", config: with(func(c *SDAProviderModel) { c.InsecureSkipVerify = types.BoolValue(false) }), env: map[string]string{"SDA_INSECURE_SKIP_VERIFY": "true"}},
+		{name: "invalid insecure env", config: null, env: map[string]string{"SDA_INSECURE_SKIP_VERIFY": "yes please"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, diags := transportConfig(tt.config, func(k string) string { return tt.env[k] })
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("diagnostics = %v, want error %v", diags, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(cfg.CACertPEM) != tt.wantCA || cfg.InsecureSkipVerify != tt.insecure || diags.WarningsCount() != tt.warnings {
+				t.Fatalf("config = %+v with %d warnings", cfg, diags.WarningsCount())
+			}
+		})
+	}
+}
+
+func TestRequestLimits(t *testing.T) {
+	null := SDAProviderModel{RequestsPerSecond: types.Float64Null(), MaxConcurrentRequests: types.Int64Null()}
+
+	limits, diags := requestLimits(null, func(k string) string {
+		return map[string]string{"SDA_REQUESTS_PER_SECOND": "2.5", "SDA_MAX_CONCURRENT_REQUESTS": "4"}[k]
+	})
+	if diags.HasError() || limits.RequestsPerSecond != 2.5 || limits.MaxConcurrentRequests != 4 {
+		t.Fatalf("limits from env = %+v, %v", limits, diags)
+	}
+
+	config := SDAProviderModel{RequestsPerSecond: types.Float64Value(10), MaxConcurrentRequests: types.Int64Value(0)}
+	limits, diags = requestLimits(config, func(string) string { return "4" })
+	if diags.HasError() || limits.RequestsPerSecond != 10 || limits.MaxConcurrentRequests != 0 {
+		t.Fatalf("limits from config = %+v, %v", limits, diags)
+	}
+
+	if _, diags := requestLimits(null, func(string) string { return "-1" }); !diags.HasError() {
+		t.Fatal("expected an error for negative limits")
+	}
+}
@@ -0,0 +1,175 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/policydocument"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var (
+	_ datasource.DataSource              = &EffectivePermissionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &EffectivePermissionsDataSource{}
+)
+
+func NewEffectivePermissionsDataSource() datasource.DataSource {
+	return &EffectivePermissionsDataSource{}
+}
+
+// EffectivePermissionsDataSource resolves the permissions a user holds through
+// their unexpired role assignments.
+type EffectivePermissionsDataSource struct {
+	client *clients.Client
+}
+
+func (d *EffectivePermissionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_permissions"
+}
+
+func (d *EffectivePermissionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resolves what a user can do from the policies of the roles assigned to them. Expired role assignments are ignored. Use `json` with the `is_allowed` function to assert permissions in `check` blocks.",
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the user.",
+			},
+			"roles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Roles assigned to the user, including expired assignments.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_role_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the role.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the role.",
+						},
+						"group_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Resource group of the role.",
+						},
+						"expiration_timestamp": schema.StringAttribute{
+							Computed:    true,
+							Description: "Date and time when the assignment expires (ISO 8601).",
+						},
+						"expired": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the assignment has expired. Policies of expired assignments are not granted.",
+						},
+					},
+				},
+			},
+			"resource_groups": schema.ListNestedAttribute{
+				Computed: true,
+				Description: "Allow-sets of the granted policies, one per policy and resource group, sorted by resource group, role and policy. The actions of an entry are allowed on its resources only. " +
+					"A resource belongs to the group it is scoped to with `assets:resource_group/<group_id>`, otherwise to the group of its role, or to `*` when the role has no group.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_group_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the resource group, or `*` for tenant wide resources.",
+						},
+						"user_role_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the role granting the policy.",
+						},
+						"policy": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the policy.",
+						},
+						"actions": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Sorted actions the policy allows.",
+						},
+						"resources": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Sorted resources of the policy in the resource group the actions apply to.",
+						},
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				Computed:    true,
+				Description: "JSON list of the policies granted by unexpired assignments, for use with the `is_allowed` function.",
+			},
+		},
+	}
+}
+
+func (d *EffectivePermissionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EffectivePermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state EffectivePermissionsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assignments, err := FetchAssignments(ctx, d.client, state.UserID.ValueString(), time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", err.Error())
+		return
+	}
+
+	state.Roles = make([]RoleModel, 0, len(assignments))
+	for _, a := range assignments {
+		state.Roles = append(state.Roles, RoleModel{
+			UserRoleID:          types.StringValue(a.Role.UserRoleID),
+			Name:                types.StringValue(a.Role.Name),
+			GroupID:             types.StringPointerValue(a.Role.GroupID),
+			ExpirationTimestamp: types.StringPointerValue(a.ExpirationTimestamp),
+			Expired:             types.BoolValue(a.Expired),
+		})
+	}
+
+	state.ResourceGroups = []ResourceGroupModel{}
+	for _, allow := range AllowSets(assignments) {
+		state.ResourceGroups = append(state.ResourceGroups, ResourceGroupModel{
+			ResourceGroupID: types.StringValue(allow.ResourceGroupID),
+			UserRoleID:      types.StringValue(allow.UserRoleID),
+			Policy:          types.StringValue(allow.Policy),
+			Actions:         allow.Actions,
+			Resources:       allow.Resources,
+		})
+	}
+
+	document, err := policydocument.Encode(ActivePolicies(assignments))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error encoding policies: %s", err))
+		return
+	}
+	state.JSON = types.StringValue(document)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,61 @@
+package permissions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/policydocument"
+)
+
+var _ function.Function = &IsAllowedFunction{}
+
+func NewIsAllowedFunction() function.Function {
+	return &IsAllowedFunction{}
+}
+
+// IsAllowedFunction evaluates a policy document offline, so that permissions
+// can be asserted in check blocks.
+type IsAllowedFunction struct{}
+
+func (f *IsAllowedFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_allowed"
+}
+
+func (f *IsAllowedFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Checks whether a policy document allows an action on a resource",
+		Description: "Returns true when any policy in the document allows the action on the resource. `*` in policy actions and resources matches any characters. The document is the `json` of `sda_effective_permissions` or `sda_policy_document`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "policies",
+				Description: "JSON list of policies.",
+			},
+			function.StringParameter{
+				Name:        "action",
+				Description: "Action to check, for example `assets:ReadDevice`.",
+			},
+			function.StringParameter{
+				Name:        "resource",
+				Description: "Resource to check, for example `assets:resource_group/<group_id>/device/<device_id>`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *IsAllowedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document, action, resource string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &document, &action, &resource))
+	if resp.Error != nil {
+		return
+	}
+
+	policies, err := policydocument.Decode(document)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, Allowed(policies, action, resource)))
+}
@@ -0,0 +1,28 @@
+package permissions
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type EffectivePermissionsModel struct {
+	UserID         types.String         `tfsdk:"user_id"`
+	Roles          []RoleModel          `tfsdk:"roles"`
+	ResourceGroups []ResourceGroupModel `tfsdk:"resource_groups"`
+	JSON           types.String         `tfsdk:"json"`
+}
+
+type RoleModel struct {
+	UserRoleID          types.String `tfsdk:"user_role_id"`
+	Name                types.String `tfsdk:"name"`
+	GroupID             types.String `tfsdk:"group_id"`
+	ExpirationTimestamp types.String `tfsdk:"expiration_timestamp"`
+	Expired             types.Bool   `tfsdk:"expired"`
+}
+
+type ResourceGroupModel struct {
+	ResourceGroupID types.String `tfsdk:"resource_group_id"`
+	UserRoleID      types.String `tfsdk:"user_role_id"`
+	Policy          types.String `tfsdk:"policy"`
+	Actions         []string     `tfsdk:"actions"`
+	Resources       []string     `tfsdk:"resources"`
+}
@@ -0,0 +1,206 @@
+// Package permissions resolves what a user is allowed to do from the policies
+// of the roles assigned to them.
+package permissions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/role"
+	"github.com/sda/terraform-provider-sda/internal/provider/validators"
+)
+
+// TenantWide is the resource group key of resources that are not scoped to a
+// single resource group.
+const TenantWide = "*"
+
+// groupResource extracts the resource group ID from resources scoped as
+// assets:resource_group/<group_id>[/...].
+var groupResource = regexp.MustCompile(`^assets:resource_group/([^/*]+)(/|$)`)
+
+// Match reports whether value matches pattern, where "*" in pattern matches
+// any sequence of characters, including none and including "/".
+func Match(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return len(value) >= len(last) && strings.HasSuffix(value, last)
+}
+
+// Allowed reports whether any of policies allows action on resource.
+func Allowed(policies []role.Policy, action, resource string) bool {
+	for _, p := range policies {
+		if matchAny(p.Action, action) && matchAny(p.Resource, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if Match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Assignment is a role assigned to a user together with the expiry of the
+// assignment.
+type Assignment struct {
+	Role                role.RoleAPIResponse
+	ExpirationTimestamp *string
+	Expired             bool
+}
+
+// Allow is the allow-set of a policy in a resource group: its actions are
+// allowed on its resources.
+type Allow struct {
+	ResourceGroupID string
+	UserRoleID      string
+	Policy          string
+	Actions         []string
+	Resources       []string
+}
+
+// FetchAssignments reads the roles assigned to userID and the expiry of each
+// assignment. Assignments expiring at or before now are marked as expired.
+func FetchAssignments(ctx context.Context, client *clients.Client, userID string, now time.Time) ([]Assignment, error) {
+	var roles []role.RoleAPIResponse
+	if err := getJSON(ctx, client, fmt.Sprintf("%s/ident/v1/user/%s/user_role", client.HostURL, url.PathEscape(userID)), &roles); err != nil {
+		return nil, fmt.Errorf("reading roles of user %s: %w", userID, err)
+	}
+
+	assignments := make([]Assignment, 0, len(roles))
+	for _, r := range roles {
+		var link struct {
+			ExpirationTimestamp *string `json:"expiration_timestamp"`
+		}
+		linkURL := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", client.HostURL, url.PathEscape(userID), url.PathEscape(r.UserRoleID))
+		if err := getJSON(ctx, client, linkURL, &link); err != nil {
+			return nil, fmt.Errorf("reading assignment of role %s to user %s: %w", r.UserRoleID, userID, err)
+		}
+
+		a := Assignment{Role: r, ExpirationTimestamp: link.ExpirationTimestamp}
+		if link.ExpirationTimestamp != nil && *link.ExpirationTimestamp != "" {
+			expiry, err := validators.ParseTimestamp(*link.ExpirationTimestamp)
+			if err != nil {
+				return nil, fmt.Errorf("assignment of role %s: %w", r.UserRoleID, err)
+			}
+			a.Expired = !expiry.After(now)
+		}
+		assignments = append(assignments, a)
+	}
+
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].Role.UserRoleID < assignments[j].Role.UserRoleID })
+	return assignments, nil
+}
+
+// ActivePolicies returns the policies of the assignments that have not expired.
+func ActivePolicies(assignments []Assignment) []role.Policy {
+	var policies []role.Policy
+	for _, a := range assignments {
+		if a.Expired {
+			continue
+		}
+		for _, p := range a.Role.Policies {
+			p.PolicyID = ""
+			policies = append(policies, p)
+		}
+	}
+	sort.SliceStable(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies
+}
+
+// AllowSets returns the allow-sets of the policies of the active assignments,
+// one per policy and resource group, so that the actions of a policy are only
+// paired with its own resources. A resource belongs to the group it is scoped
+// to with assets:resource_group/<group_id>, otherwise to the group of its role,
+// and to TenantWide when the role has no group either.
+func AllowSets(assignments []Assignment) []Allow {
+	allows := []Allow{}
+	for _, a := range assignments {
+		if a.Expired {
+			continue
+		}
+		roleGroup := TenantWide
+		if a.Role.GroupID != nil && *a.Role.GroupID != "" {
+			roleGroup = *a.Role.GroupID
+		}
+		for _, p := range a.Role.Policies {
+			resources := map[string]map[string]bool{}
+			for _, resource := range p.Resource {
+				group := roleGroup
+				if m := groupResource.FindStringSubmatch(resource); m != nil {
+					group = m[1]
+				}
+				if resources[group] == nil {
+					resources[group] = map[string]bool{}
+				}
+				resources[group][resource] = true
+			}
+
+			actions := map[string]bool{}
+			for _, action := range p.Action {
+				actions[action] = true
+			}
+			for group, rs := range resources {
+				allows = append(allows, Allow{ResourceGroupID: group, UserRoleID: a.Role.UserRoleID, Policy: p.Name, Actions: keys(actions), Resources: keys(rs)})
+			}
+		}
+	}
+
+	sort.Slice(allows, func(i, j int) bool {
+		if allows[i].ResourceGroupID != allows[j].ResourceGroupID {
+			return allows[i].ResourceGroupID < allows[j].ResourceGroupID
+		}
+		if allows[i].UserRoleID != allows[j].UserRoleID {
+			return allows[i].UserRoleID < allows[j].UserRoleID
+		}
+		return allows[i].Policy < allows[j].Policy
+	})
+	return allows
+}
+
+func getJSON(ctx context.Context, client *clients.Client, url string, v any) error {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	body, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func keys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,145 @@
+package permissions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, value string
+		want           bool
+	}{
+		{"*", "assets:ReadDevice", true},
+		{"assets:Read*", "assets:ReadDevice", true},
+		{"assets:Read*", "assets:UpdateDevice", false},
+		{"assets:*Device", "assets:DeleteDevice", true},
+		{"assets:*Device", "assets:DeleteDeviceGroup", false},
+		{"assets:resource_group/g1/*", "assets:resource_group/g1/device/d1", true},
+		{"assets:resource_group/g1/*", "assets:resource_group/g10/device/d1", false},
+		{"assets:resource_group/*/device/*", "assets:resource_group/g1/device/d1", true},
+		{"a*a", "a", false},
+		{"assets:ReadDevice", "assets:ReadDevice", true},
+		{"assets:ReadDevice", "assets:ReadDevices", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.value); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFetchAssignments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ident/v1/user/u1/user_role":
+			w.Write([]byte(`[
+				{"user_role_id": "r2", "name": "break-glass", "is_system_role": false,
+				 "policies": [{"policy_id": "p2", "name": "all", "action": ["*"], "resource": ["*"]}]},
+				{"user_role_id": "r1", "name": "operator", "group_id": "plant-1", "is_system_role": false,
+				 "policies": [
+				   {"policy_id": "p1", "name": "read", "action": ["assets:Read*"], "resource": ["assets:device/*", "assets:resource_group/plant-2/*"]},
+				   {"policy_id": "p3", "name": "update", "action": ["assets:UpdateDevice"], "resource": ["assets:resource_group/plant-1/device/d1"]}
+				 ]}
+			]`))
+		case "/ident/v1/user_role_user_link/user/u1/user_role/r1":
+			w.Write([]byte(`{"user_id": "u1", "user_role_id": "r1", "expiration_timestamp": null}`))
+		case "/ident/v1/user_role_user_link/user/u1/user_role/r2":
+			w.Write([]byte(`{"user_id": "u1", "user_role_id": "r2", "expiration_timestamp": "2026-01-01T00:00:00"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	assignments, err := FetchAssignments(context.Background(), client, "u1", now)
+	if err != nil {
+		t.Fatalf("FetchAssignments() error: %v", err)
+	}
+	if len(assignments) != 2 || assignments[0].Role.UserRoleID != "r1" || assignments[0].Expired || !assignments[1].Expired {
+		t.Fatalf("unexpected assignments: %+v", assignments)
+	}
+
+	policies := ActivePolicies(assignments)
+	if len(policies) != 2 || policies[0].Name != "read" || policies[0].PolicyID != "" {
+		t.Fatalf("expired assignment should not grant policies: %+v", policies)
+	}
+	if Allowed(policies, "assets:DeleteDevice", "assets:device/d1") {
+		t.Error("expired break-glass role must not allow deletes")
+	}
+	if !Allowed(policies, "assets:ReadDevice", "assets:device/d1") {
+		t.Error("operator role should allow reading devices")
+	}
+
+	// The update policy of plant-1 only applies to its own device.
+	want := []Allow{
+		{ResourceGroupID: "plant-1", UserRoleID: "r1", Policy: "read", Actions: []string{"assets:Read*"}, Resources: []string{"assets:device/*"}},
+		{ResourceGroupID: "plant-1", UserRoleID: "r1", Policy: "update", Actions: []string{"assets:UpdateDevice"}, Resources: []string{"assets:resource_group/plant-1/device/d1"}},
+		{ResourceGroupID: "plant-2", UserRoleID: "r1", Policy: "read", Actions: []string{"assets:Read*"}, Resources: []string{"assets:resource_group/plant-2/*"}},
+	}
+	if got := AllowSets(assignments); !reflect.DeepEqual(got, want) {
+		t.Fatalf("AllowSets() = %+v, want %+v", got, want)
+	}
+
+	// Once the break-glass assignment is active it is granted tenant wide.
+	assignments, err = FetchAssignments(context.Background(), client, "u1", now.AddDate(-1, 0, 0))
+	if err != nil {
+		t.Fatalf("FetchAssignments() error: %v", err)
+	}
+	if got := AllowSets(assignments); len(got) != 4 || got[0].ResourceGroupID != TenantWide {
+		t.Fatalf("expected a tenant wide allow-set, got %+v", got)
+	}
+}
+
+func TestIsAllowedFunction(t *testing.T) {
+	ctx := context.Background()
+	document := `[{"name":"read","action":["assets:Read*"],"resource":["assets:resource_group/g1/*"],"description":null}]`
+
+	tests := []struct {
+		action, resource string
+		want             bool
+	}{
+		{"assets:ReadDevice", "assets:resource_group/g1/device/d1", true},
+		{"assets:DeleteDevice", "assets:resource_group/g1/device/d1", false},
+		{"assets:ReadDevice", "assets:resource_group/g2/device/d1", false},
+	}
+	for _, tt := range tests {
+		req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+			basetypes.NewStringValue(document),
+			basetypes.NewStringValue(tt.action),
+			basetypes.NewStringValue(tt.resource),
+		})}
+		resp := function.RunResponse{Result: function.NewResultData(basetypes.NewBoolUnknown())}
+		NewIsAllowedFunction().Run(ctx, req, &resp)
+		if resp.Error != nil {
+			t.Fatalf("Run() error: %s", resp.Error)
+		}
+		if got := resp.Result.Value().(basetypes.BoolValue).ValueBool(); got != tt.want {
+			t.Errorf("is_allowed(%q, %q) = %v, want %v", tt.action, tt.resource, got, tt.want)
+		}
+	}
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		basetypes.NewStringValue(`not json`),
+		basetypes.NewStringValue("assets:ReadDevice"),
+		basetypes.NewStringValue("*"),
+	})}
+	resp := function.RunResponse{Result: function.NewResultData(basetypes.NewBoolUnknown())}
+	NewIsAllowedFunction().Run(ctx, req, &resp)
+	if resp.Error == nil {
+		t.Fatal("expected an error for an invalid policy document")
+	}
+}
@@ -0,0 +1,211 @@
+package policydocument
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/role"
+)
+
+var _ datasource.DataSource = &PolicyDocumentDataSource{}
+
+func NewPolicyDocumentDataSource() datasource.DataSource {
+	return &PolicyDocumentDataSource{}
+}
+
+// PolicyDocumentDataSource composes role policies locally without calling the API.
+type PolicyDocumentDataSource struct{}
+
+func (d *PolicyDocumentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_document"
+}
+
+func (d *PolicyDocumentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Composes role policies from asset types, resource group scopes and action sets, and merges them with base documents. The result can be passed to `sda_role` policy blocks.",
+		Attributes: map[string]schema.Attribute{
+			"source_policy_documents": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Policy documents, usually the `json` of another `sda_policy_document`, that this document extends. Statements replace source policies of the same name. Policy names must be unique across all source documents.",
+			},
+			"override_policy_documents": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Policy documents whose policies replace policies of the same name from the source documents and statements. Later documents win.",
+			},
+			"json": schema.StringAttribute{
+				Computed:    true,
+				Description: "Canonical JSON list of the merged policies in the format of the Ident Service API.",
+			},
+			"policies": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Merged policies sorted by name, for use in `dynamic \"policy\"` blocks of `sda_role`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the policy.",
+						},
+						"actions": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Sorted actions allowed by the policy.",
+						},
+						"resources": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Sorted resources the actions apply to.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the policy.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"statement": schema.ListNestedBlock{
+				Description: "Policy statement. Each statement becomes one policy.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name of the policy. Used to merge with source and override documents.",
+						},
+						"description": schema.StringAttribute{
+							Optional:    true,
+							Description: "Description of the policy.",
+						},
+						"actions": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Actions granted verbatim, either `*` or `<service>:<verb>`.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.RegexMatches(role.ActionPattern, "must be * or <service>:<verb>")),
+							},
+						},
+						"action_sets": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Named sets of actions: `read`, `write` (create and update), `delete`, `download` or `full`. Each set grants `assets:<Verb><AssetType>` for every asset type in `asset_types`, or `assets:<Verb>*` when no asset types are given.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.OneOf(ActionSetNames...)),
+							},
+						},
+						"asset_types": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Asset types the statement applies to. Adds `assets:<asset_type>/*` resources, scoped to `resource_group_ids` when given.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(enums.OneOf(enums.AssetTypeEnum)),
+							},
+						},
+						"resource_group_ids": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Resource groups the statement is scoped to. Adds `assets:resource_group/<group_id>/*` resources, or `assets:resource_group/<group_id>/<asset_type>/*` when `asset_types` is set.",
+						},
+						"resources": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Resources added verbatim, either `*` or `<service>:<type>/<id>`.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.RegexMatches(role.ResourcePattern, "must be * or <service>:<type>/<id>")),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PolicyDocumentModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sources := d.decodeDocuments(ctx, "source_policy_documents", config.SourcePolicyDocuments, resp)
+	overrides := d.decodeDocuments(ctx, "override_policy_documents", config.OverridePolicyDocuments, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var statements []role.Policy
+	for _, s := range config.Statements {
+		statement := Statement{
+			Name:        s.Name.ValueString(),
+			Description: s.Description.ValueStringPointer(),
+		}
+		resp.Diagnostics.Append(s.Actions.ElementsAs(ctx, &statement.Actions, false)...)
+		resp.Diagnostics.Append(s.ActionSets.ElementsAs(ctx, &statement.ActionSets, false)...)
+		resp.Diagnostics.Append(s.AssetTypes.ElementsAs(ctx, &statement.AssetTypes, false)...)
+		resp.Diagnostics.Append(s.ResourceGroupIDs.ElementsAs(ctx, &statement.ResourceGroupIDs, false)...)
+		resp.Diagnostics.Append(s.Resources.ElementsAs(ctx, &statement.Resources, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		p, err := statement.Policy()
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Policy Statement", err.Error())
+			return
+		}
+		statements = append(statements, p)
+	}
+
+	policies, err := Merge(sources, statements, overrides)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Policy Document", err.Error())
+		return
+	}
+
+	document, err := Encode(policies)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error encoding policy document: %s", err))
+		return
+	}
+	config.JSON = types.StringValue(document)
+
+	models := make([]PolicyOutputModel, 0, len(policies))
+	for _, p := range policies {
+		models = append(models, PolicyOutputModel{
+			Name:        types.StringValue(p.Name),
+			Actions:     p.Action,
+			Resources:   p.Resource,
+			Description: types.StringPointerValue(p.Description),
+		})
+	}
+	config.Policies = models
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// decodeDocuments parses the policy documents of the list attribute name.
+func (d *PolicyDocumentDataSource) decodeDocuments(ctx context.Context, name string, list types.List, resp *datasource.ReadResponse) [][]role.Policy {
+	var documents []string
+	resp.Diagnostics.Append(list.ElementsAs(ctx, &documents, false)...)
+
+	var decoded [][]role.Policy
+	for i, document := range documents {
+		policies, err := Decode(document)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Policy Document", fmt.Sprintf("%s[%d]: %s", name, i, err))
+			continue
+		}
+		decoded = append(decoded, policies)
+	}
+	return decoded
+}
@@ -0,0 +1,210 @@
+package policydocument
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/role"
+)
+
+// ActionSets maps the action set names accepted by statements to the verbs of
+// the Assets Management Service they grant.
+var ActionSets = map[string][]string{
+	"read":     {"Read"},
+	"write":    {"Create", "Update"},
+	"delete":   {"Delete"},
+	"download": {"Download"},
+	"full":     {"*"},
+}
+
+// ActionSetNames lists the keys of ActionSets in a stable order.
+var ActionSetNames = []string{"read", "write", "delete", "download", "full"}
+
+// Statement is a policy statement before its action sets, asset types and
+// resource group scopes are expanded into actions and resources.
+type Statement struct {
+	Name             string
+	Description      *string
+	Actions          []string
+	ActionSets       []string
+	AssetTypes       []string
+	ResourceGroupIDs []string
+	Resources        []string
+}
+
+// Policy expands s into a canonical policy.
+//
+// Each action set grants assets:<Verb><AssetType> for every asset type, or
+// assets:<Verb>* when no asset types are given. Asset types and resource
+// groups scope the resources as assets:resource_group/<group_id>/<asset_type>/*,
+// where either part is left out when not given.
+func (s Statement) Policy() (role.Policy, error) {
+	actions := append([]string{}, s.Actions...)
+	for _, set := range s.ActionSets {
+		verbs, ok := ActionSets[set]
+		if !ok {
+			return role.Policy{}, fmt.Errorf("statement %q: unknown action set %q", s.Name, set)
+		}
+		for _, verb := range verbs {
+			if len(s.AssetTypes) == 0 {
+				actions = append(actions, "assets:"+strings.TrimSuffix(verb, "*")+"*")
+				continue
+			}
+			for _, assetType := range s.AssetTypes {
+				actions = append(actions, "assets:"+verb+pascalCase(assetType))
+			}
+		}
+	}
+
+	resources := append([]string{}, s.Resources...)
+	switch {
+	case len(s.ResourceGroupIDs) > 0 && len(s.AssetTypes) > 0:
+		for _, groupID := range s.ResourceGroupIDs {
+			for _, assetType := range s.AssetTypes {
+				resources = append(resources, fmt.Sprintf("assets:resource_group/%s/%s/*", groupID, strings.ToLower(assetType)))
+			}
+		}
+	case len(s.ResourceGroupIDs) > 0:
+		for _, groupID := range s.ResourceGroupIDs {
+			resources = append(resources, fmt.Sprintf("assets:resource_group/%s/*", groupID))
+		}
+	case len(s.AssetTypes) > 0:
+		for _, assetType := range s.AssetTypes {
+			resources = append(resources, fmt.Sprintf("assets:%s/*", strings.ToLower(assetType)))
+		}
+	}
+
+	p := role.Policy{Name: s.Name, Action: actions, Resource: resources, Description: s.Description}
+	if err := validate(p); err != nil {
+		return role.Policy{}, err
+	}
+	return canonical(p), nil
+}
+
+// Merge combines policy documents the way aws_iam_policy_document does:
+// source policies come first, statements replace source policies of the same
+// name and overrides replace policies of the same name from either. Policies
+// without a counterpart are appended. The result is sorted by name.
+func Merge(sources [][]role.Policy, statements []role.Policy, overrides [][]role.Policy) ([]role.Policy, error) {
+	byName := map[string]role.Policy{}
+	fromSource := map[string]bool{}
+
+	for i, doc := range sources {
+		for _, p := range doc {
+			if fromSource[p.Name] {
+				return nil, fmt.Errorf("source_policy_documents[%d]: duplicate policy name %q", i, p.Name)
+			}
+			fromSource[p.Name] = true
+			byName[p.Name] = p
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, p := range statements {
+		if seen[p.Name] {
+			return nil, fmt.Errorf("duplicate statement name %q", p.Name)
+		}
+		seen[p.Name] = true
+		byName[p.Name] = p
+	}
+
+	for _, doc := range overrides {
+		for _, p := range doc {
+			byName[p.Name] = p
+		}
+	}
+
+	policies := make([]role.Policy, 0, len(byName))
+	for _, p := range byName {
+		policies = append(policies, canonical(p))
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies, nil
+}
+
+// Decode parses a policy document rendered by the json attribute, or a list of
+// policies in the format of the Ident Service API.
+func Decode(document string) ([]role.Policy, error) {
+	var policies []role.Policy
+	if err := json.Unmarshal([]byte(document), &policies); err != nil {
+		return nil, fmt.Errorf("invalid policy document: %w", err)
+	}
+	for i, p := range policies {
+		if p.Name == "" {
+			return nil, fmt.Errorf("policy %d has no name", i)
+		}
+		if err := validate(p); err != nil {
+			return nil, err
+		}
+		policies[i].PolicyID = ""
+	}
+	return policies, nil
+}
+
+// Encode renders policies as canonical JSON.
+func Encode(policies []role.Policy) (string, error) {
+	b, err := json.Marshal(policies)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func validate(p role.Policy) error {
+	if len(p.Action) == 0 {
+		return fmt.Errorf("policy %q grants no actions", p.Name)
+	}
+	if len(p.Resource) == 0 {
+		return fmt.Errorf("policy %q applies to no resources", p.Name)
+	}
+	for _, action := range p.Action {
+		if !role.ActionPattern.MatchString(action) {
+			return fmt.Errorf("policy %q: action %q must be * or <service>:<verb>", p.Name, action)
+		}
+	}
+	for _, resource := range p.Resource {
+		if !role.ResourcePattern.MatchString(resource) {
+			return fmt.Errorf("policy %q: resource %q must be * or <service>:<type>/<id>", p.Name, resource)
+		}
+	}
+	return nil
+}
+
+// canonical sorts and deduplicates the actions and resources of p and drops an
+// empty description.
+func canonical(p role.Policy) role.Policy {
+	p.PolicyID = ""
+	p.Action = sortedUnique(p.Action)
+	p.Resource = sortedUnique(p.Resource)
+	if p.Description != nil && *p.Description == "" {
+		p.Description = nil
+	}
+	return p
+}
+
+func sortedUnique(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := map[string]bool{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// pascalCase turns an AssetTypeEnum value such as RESOURCE_GROUP into ResourceGroup.
+func pascalCase(assetType string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(strings.ToLower(assetType), "_") {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
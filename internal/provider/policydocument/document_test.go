@@ -0,0 +1,143 @@
+package policydocument
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/role"
+)
+
+func TestStatementPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement Statement
+		actions   []string
+		resources []string
+	}{
+		{
+			name:      "asset types in resource groups",
+			statement: Statement{Name: "s", ActionSets: []string{"read", "write"}, AssetTypes: []string{"DEVICE", "RESOURCE_GROUP"}, ResourceGroupIDs: []string{"g1"}},
+			actions:   []string{"assets:CreateDevice", "assets:CreateResourceGroup", "assets:ReadDevice", "assets:ReadResourceGroup", "assets:UpdateDevice", "assets:UpdateResourceGroup"},
+			resources: []string{"assets:resource_group/g1/device/*", "assets:resource_group/g1/resource_group/*"},
+		},
+		{
+			name:      "resource groups only",
+			statement: Statement{Name: "s", ActionSets: []string{"full"}, ResourceGroupIDs: []string{"g2", "g1"}},
+			actions:   []string{"assets:*"},
+			resources: []string{"assets:resource_group/g1/*", "assets:resource_group/g2/*"},
+		},
+		{
+			name:      "asset types only with verbatim entries",
+			statement: Statement{Name: "s", Actions: []string{"ident:ReadUser"}, ActionSets: []string{"read"}, AssetTypes: []string{"PROJECT"}, Resources: []string{"*"}},
+			actions:   []string{"assets:ReadProject", "ident:ReadUser"},
+			resources: []string{"*", "assets:project/*"},
+		},
+		{
+			name:      "action sets without asset types",
+			statement: Statement{Name: "s", ActionSets: []string{"read", "download", "read"}, Resources: []string{"assets:*"}},
+			actions:   []string{"assets:Download*", "assets:Read*"},
+			resources: []string{"assets:*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.statement.Policy()
+			if err != nil {
+				t.Fatalf("Policy() error: %v", err)
+			}
+			if !reflect.DeepEqual(p.Action, tt.actions) {
+				t.Errorf("actions = %v, want %v", p.Action, tt.actions)
+			}
+			if !reflect.DeepEqual(p.Resource, tt.resources) {
+				t.Errorf("resources = %v, want %v", p.Resource, tt.resources)
+			}
+		})
+	}
+}
+
+func TestStatementPolicyErrors(t *testing.T) {
+	tests := map[string]Statement{
+		"no actions":       {Name: "s", Resources: []string{"*"}},
+		"no resources":     {Name: "s", Actions: []string{"*"}},
+		"unknown set":      {Name: "s", ActionSets: []string{"admin"}, Resources: []string{"*"}},
+		"invalid group id": {Name: "s", ActionSets: []string{"read"}, ResourceGroupIDs: []string{"my group"}},
+		"invalid action":   {Name: "s", Actions: []string{"Read"}, Resources: []string{"*"}},
+		"invalid resource": {Name: "s", Actions: []string{"*"}, Resources: []string{"device/*"}},
+	}
+	for name, statement := range tests {
+		if _, err := statement.Policy(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := []role.Policy{
+		{Name: "read", Action: []string{"assets:Read*"}, Resource: []string{"*"}},
+		{Name: "deploy", Action: []string{"assets:UpdateDevice"}, Resource: []string{"*"}},
+	}
+	statements := []role.Policy{
+		{Name: "deploy", Action: []string{"assets:UpdateDevice"}, Resource: []string{"assets:resource_group/plant-1/*"}},
+		{Name: "download", Action: []string{"assets:Download*"}, Resource: []string{"assets:resource_group/plant-1/*"}},
+	}
+	overrides := [][]role.Policy{{{Name: "read", Action: []string{"assets:ReadDevice"}, Resource: []string{"*"}}}}
+
+	policies, err := Merge([][]role.Policy{base}, statements, overrides)
+	if err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+
+	got := map[string]role.Policy{}
+	var names []string
+	for _, p := range policies {
+		got[p.Name] = p
+		names = append(names, p.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"deploy", "download", "read"}) {
+		t.Fatalf("policies not sorted by name: %v", names)
+	}
+	if got["deploy"].Resource[0] != "assets:resource_group/plant-1/*" {
+		t.Errorf("statement did not replace source policy: %+v", got["deploy"])
+	}
+	if got["read"].Action[0] != "assets:ReadDevice" {
+		t.Errorf("override did not replace policy: %+v", got["read"])
+	}
+
+	if _, err := Merge([][]role.Policy{base, base}, nil, nil); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("expected duplicate source error, got %v", err)
+	}
+	if _, err := Merge(nil, append(statements, statements[0]), nil); err == nil {
+		t.Error("expected duplicate statement error")
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	policies, err := Merge(nil, []role.Policy{{Name: "all", Action: []string{"*"}, Resource: []string{"*"}}}, nil)
+	if err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+
+	document, err := Encode(policies)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+	if want := `[{"name":"all","action":["*"],"resource":["*"],"description":null}]`; document != want {
+		t.Fatalf("Encode() = %s, want %s", document, want)
+	}
+
+	decoded, err := Decode(`[{"policy_id":"p-1","name":"all","action":["*"],"resource":["*"]}]`)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, policies) {
+		t.Fatalf("Decode() = %+v, want %+v", decoded, policies)
+	}
+
+	for _, document := range []string{`{}`, `[{"action":["*"],"resource":["*"]}]`, `[{"name":"x","action":["Read"],"resource":["*"]}]`} {
+		if _, err := Decode(document); err == nil {
+			t.Errorf("Decode(%s): expected an error", document)
+		}
+	}
+}
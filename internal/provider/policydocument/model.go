@@ -0,0 +1,30 @@
+package policydocument
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type PolicyDocumentModel struct {
+	SourcePolicyDocuments   types.List          `tfsdk:"source_policy_documents"`
+	OverridePolicyDocuments types.List          `tfsdk:"override_policy_documents"`
+	Statements              []StatementModel    `tfsdk:"statement"`
+	JSON                    types.String        `tfsdk:"json"`
+	Policies                []PolicyOutputModel `tfsdk:"policies"`
+}
+
+type StatementModel struct {
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	Actions          types.Set    `tfsdk:"actions"`
+	ActionSets       types.Set    `tfsdk:"action_sets"`
+	AssetTypes       types.Set    `tfsdk:"asset_types"`
+	ResourceGroupIDs types.Set    `tfsdk:"resource_group_ids"`
+	Resources        types.Set    `tfsdk:"resources"`
+}
+
+type PolicyOutputModel struct {
+	Name        types.String `tfsdk:"name"`
+	Actions     []string     `tfsdk:"actions"`
+	Resources   []string     `tfsdk:"resources"`
+	Description types.String `tfsdk:"description"`
+}
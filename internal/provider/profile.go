@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+// profileKeys maps the keys of a profile to the environment variables they
+// stand in for.
+var profileKeys = map[string]string{
+	"host":                    "SDA_HOST",
+	"tenant_id":               "SDA_TENANT_ID",
+	"username":                "SDA_USERNAME",
+	"password":                "SDA_PASSWORD",
+	"token":                   "SDA_TOKEN",
+	"token_file":              "SDA_TOKEN_FILE",
+	"refresh_token":           "SDA_REFRESH_TOKEN",
+	"oidc_token_env":          "SDA_OIDC_TOKEN_ENV",
+	"oidc_audience":           "SDA_OIDC_AUDIENCE",
+	"credential_process":      "SDA_CREDENTIAL_PROCESS",
+	"proxy_url":               "SDA_PROXY_URL",
+	"ca_cert_file":            "SDA_CA_CERT_FILE",
+	"client_cert":             "SDA_CLIENT_CERT",
+	"client_key":              "SDA_CLIENT_KEY",
+	"insecure_skip_verify":    "SDA_INSECURE_SKIP_VERIFY",
+	"requests_per_second":     "SDA_REQUESTS_PER_SECOND",
+	"max_concurrent_requests": "SDA_MAX_CONCURRENT_REQUESTS",
+}
+
+// credentialProcessTimeout bounds the run time of a credential_process.
+const credentialProcessTimeout = time.Minute
+
+// credentialsFile returns the path of the shared credentials file,
+// SDA_CREDENTIALS_FILE or ~/.sda/credentials.
+func credentialsFile(getenv func(string) string) (string, error) {
+	if file := getenv("SDA_CREDENTIALS_FILE"); file != "" {
+		return file, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".sda", "credentials"), nil
+}
+
+// loadProfile reads the profile name of the INI style credentials file, and
+// returns its settings keyed by the environment variable they stand in for.
+func loadProfile(file, name string) (map[string]string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		section  string
+		found    bool
+		settings = map[string]string{}
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			found = found || section == name
+			continue
+		}
+		if section != name {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", file, n)
+		}
+		key = strings.TrimSpace(key)
+		envVar, known := profileKeys[key]
+		if !known {
+			return nil, fmt.Errorf("%s:%d: unknown key %q in profile %s", file, n, key, name)
+		}
+		settings[envVar] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("profile %s not found in %s", name, file)
+	}
+	return settings, nil
+}
+
+// withProfile returns a getenv that falls back to the settings of a profile
+// for environment variables that are not set.
+func withProfile(getenv func(string) string, settings map[string]string) func(string) string {
+	return func(key string) string {
+		if v := getenv(key); v != "" {
+			return v
+		}
+		return settings[key]
+	}
+}
+
+// runCredentialProcess runs command, split on whitespace without a shell, and
+// parses the credentials it prints to stdout as a JSON object with either
+// username and password, token or refresh_token.
+func runCredentialProcess(ctx context.Context, command string) (clients.Credentials, error) {
+	var creds clients.Credentials
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return creds, fmt.Errorf("credential_process is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return creds, fmt.Errorf("running %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var out struct {
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		// The output holds secrets, so it is not included in the error.
+		return creds, fmt.Errorf("the output of %s is not a JSON object: %w", args[0], err)
+	}
+
+	methods := 0
+	for _, set := range []bool{out.Username != "" || out.Password != "", out.Token != "", out.RefreshToken != ""} {
+		if set {
+			methods++
+		}
+	}
+	switch {
+	case methods != 1:
+		return creds, fmt.Errorf("%s must print exactly one of username and password, token or refresh_token", args[0])
+	case (out.Username == "") != (out.Password == ""):
+		return creds, fmt.Errorf("%s must print both username and password", args[0])
+	}
+
+	creds.Username, creds.Password = out.Username, out.Password
+	creds.Token, creds.RefreshToken = out.Token, out.RefreshToken
+	return creds, nil
+}
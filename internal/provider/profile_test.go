@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	content := `# SDA profiles
+[dev]
+host = https://dev.sda.example.com
+username = jane@example.com
+
+[prod]
+host      = https://sda.example.com
+tenant_id = t-prod
+credential_process = pass-sda prod
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := loadProfile(file, "prod")
+	if err != nil {
+		t.Fatalf("loadProfile: %s", err)
+	}
+	want := map[string]string{"SDA_HOST": "https://sda.example.com", "SDA_TENANT_ID": "t-prod", "SDA_CREDENTIAL_PROCESS": "pass-sda prod"}
+	if !reflect.DeepEqual(settings, want) {
+		t.Fatalf("settings = %v, want %v", settings, want)
+	}
+
+	getenv := withProfile(func(k string) string { return map[string]string{"SDA_HOST": "https://override"}[k] }, settings)
+	if getenv("SDA_HOST") != "https://override" || getenv("SDA_TENANT_ID") != "t-prod" {
+		t.Fatalf("environment variables must take precedence over the profile")
+	}
+
+	if _, err := loadProfile(file, "staging"); err == nil {
+		t.Fatalf("expected an error for a missing profile")
+	}
+
+	if err := os.WriteFile(file, []byte("[dev]\nhots = typo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadProfile(file, "dev"); err == nil {
+		t.Fatalf("expected an error for an unknown key")
+	}
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	dir := t.TempDir()
+	script := func(name, output string) string {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte("#!/bin/sh\nprintf '%s' '"+output+"'\n"), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	creds, err := runCredentialProcess(context.Background(), script("password", `{"username": "jane", "password": "secret"}`)+" --profile prod")
+	if err != nil || creds.Username != "jane" || creds.Password != "secret" {
+		t.Fatalf("runCredentialProcess = %+v, %v", creds, err)
+	}
+
+	for name, output := range map[string]string{
+		"two-methods":  `{"token": "t", "refresh_token": "r"}`,
+		"no-password":  `{"username": "jane"}`,
+		"not-json":     `secret`,
+		"empty-object": `{}`,
+	} {
+		if _, err := runCredentialProcess(context.Background(), script(name, output)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
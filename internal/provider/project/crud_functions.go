@@ -16,6 +16,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 const multipartChunkSize = 5 * 1024 * 1024 // 5MB per part
@@ -25,6 +27,10 @@ const multipartChunkSize = 5 * 1024 * 1024 // 5MB per part
 //-----------------------------------------------------------------
 
 func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan ProjectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -117,7 +123,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/project", r.client.HostURL)
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
@@ -163,7 +169,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		chunk := chunks[i]
 
 		// Upload chunk
-		uploadReq, err := http.NewRequest(http.MethodPut, uploadURL.UploadURL, bytes.NewReader(chunk))
+		uploadReq, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL.UploadURL, bytes.NewReader(chunk))
 		if err != nil {
 			resp.Diagnostics.AddError("Upload Error", fmt.Sprintf("Error creating upload request: %s", err))
 			return
@@ -171,7 +177,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 
 		// MD5 is already included in the pre-signed URL, no need to set it in headers
 
-		uploadResp, err := http.DefaultClient.Do(uploadReq)
+		uploadResp, err := r.client.Upload(uploadReq)
 		if err != nil {
 			resp.Diagnostics.AddError("Upload Error", fmt.Sprintf("Error uploading part %d: %s", uploadURL.PartNumber, err))
 			return
@@ -216,7 +222,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		createResp.UploadID,
 	)
 
-	completeReq, err := http.NewRequest(http.MethodPost, completeURL, bytes.NewReader(completeBody))
+	completeReq, err := http.NewRequestWithContext(ctx, http.MethodPost, completeURL, bytes.NewReader(completeBody))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating complete request: %s", err))
 		return
@@ -266,20 +272,26 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ProjectIdentityModel{ProjectID: state.ProjectID})...)
 }
 
 //-----------------------------------------------------------------
 //         READ
 //-----------------------------------------------------------------
 func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state ProjectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ProjectIdentityModel{ProjectID: state.ProjectID})...)
 
 	url := fmt.Sprintf("%s/assets/v1/project/%s", r.client.HostURL, state.ProjectID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
@@ -311,35 +323,7 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// Build attached_licenses list
-	var attachedLicensesList basetypes.ListValue
-	if apiResp.AttachedLicenses != nil && len(apiResp.AttachedLicenses) > 0 {
-		licensesElements := make([]types.String, len(apiResp.AttachedLicenses))
-		for i, lic := range apiResp.AttachedLicenses {
-			licensesElements[i] = types.StringValue(lic)
-		}
-		var diags = resp.Diagnostics
-		attachedLicensesList, _ = types.ListValueFrom(ctx, types.StringType, licensesElements)
-		resp.Diagnostics = diags
-	} else {
-		attachedLicensesList = types.ListNull(types.StringType)
-	}
-
-	state.ObjectVersion = types.Int64Value(apiResp.ObjectVersion)
-	state.CreationUserID = types.StringValue(apiResp.CreationUserID)
-	state.UpdateUserID = types.StringPointerValue(apiResp.UpdateUserID)
-	state.CreationTimestamp = types.StringValue(apiResp.CreationTimestamp)
-	state.UpdateTimestamp = types.StringPointerValue(apiResp.UpdateTimestamp)
-	state.ProjectID = types.StringValue(apiResp.ProjectID)
-	state.GroupID = types.StringPointerValue(apiResp.GroupID)
-	state.Name = types.StringValue(apiResp.Name)
-	state.VendorID = types.StringValue(apiResp.VendorID)
-	state.IdeConfigID = types.StringValue(apiResp.IdeConfigID)
-	state.ProjectType = types.StringValue(apiResp.ProjectType)
-	state.LastVersionNumber = types.Int64Value(apiResp.LastVersionNumber)
-	state.Description = types.StringPointerValue(apiResp.Description)
-	state.SecretID = types.StringPointerValue(apiResp.SecretID)
-	state.AttachedLicenses = attachedLicensesList
+	setProjectState(ctx, &state, &apiResp)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -348,6 +332,10 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 //         UPDATE
 //-----------------------------------------------------------------
 func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state ProjectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -412,7 +400,7 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	url := fmt.Sprintf("%s/assets/v1/project/%s", r.client.HostURL, state.ProjectID.ValueString())
 
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
@@ -475,12 +463,17 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 	state.AttachedLicenses = attachedLicensesList
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ProjectIdentityModel{ProjectID: state.ProjectID})...)
 }
 
 //-----------------------------------------------------------------
 //         DELETE
 //-----------------------------------------------------------------
 func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state ProjectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -488,7 +481,7 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/project/%s", r.client.HostURL, state.ProjectID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
@@ -503,4 +496,33 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error deleting project: %s", err))
 	}
-}
\ No newline at end of file
+}
+
+//-----------------------------------------------------------------
+//         HELPER FUNCTIONS
+//-----------------------------------------------------------------
+
+// setProjectState copies the fields returned by the API into state. Fields
+// that only exist in configuration, such as file_path, are left untouched.
+func setProjectState(ctx context.Context, state *ProjectResourceModel, apiResp *ProjectAPIResponse) {
+	attachedLicensesList := types.ListNull(types.StringType)
+	if len(apiResp.AttachedLicenses) > 0 {
+		attachedLicensesList, _ = types.ListValueFrom(ctx, types.StringType, apiResp.AttachedLicenses)
+	}
+
+	state.ObjectVersion = types.Int64Value(apiResp.ObjectVersion)
+	state.CreationUserID = types.StringValue(apiResp.CreationUserID)
+	state.UpdateUserID = types.StringPointerValue(apiResp.UpdateUserID)
+	state.CreationTimestamp = types.StringValue(apiResp.CreationTimestamp)
+	state.UpdateTimestamp = types.StringPointerValue(apiResp.UpdateTimestamp)
+	state.ProjectID = types.StringValue(apiResp.ProjectID)
+	state.GroupID = types.StringPointerValue(apiResp.GroupID)
+	state.Name = types.StringValue(apiResp.Name)
+	state.VendorID = types.StringValue(apiResp.VendorID)
+	state.IdeConfigID = types.StringValue(apiResp.IdeConfigID)
+	state.ProjectType = types.StringValue(apiResp.ProjectType)
+	state.LastVersionNumber = types.Int64Value(apiResp.LastVersionNumber)
+	state.Description = types.StringPointerValue(apiResp.Description)
+	state.SecretID = types.StringPointerValue(apiResp.SecretID)
+	state.AttachedLicenses = attachedLicensesList
+}
@@ -0,0 +1,125 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var _ list.ListResource = &ProjectListResource{}
+var _ list.ListResourceWithConfigure = &ProjectListResource{}
+
+func NewProjectListResource() list.ListResource {
+	return &ProjectListResource{}
+}
+
+// ProjectListResource enumerates the projects of the tenant for `terraform query`.
+type ProjectListResource struct {
+	client *clients.Client
+}
+
+// ProjectListConfigModel maps the list block configuration.
+type ProjectListConfigModel struct {
+	GroupID types.String `tfsdk:"group_id"`
+}
+
+func (r *ProjectListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *ProjectListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the projects of the tenant in the SDA Assets Management Service.",
+		Attributes: map[string]listschema.Attribute{
+			"group_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list projects that belong to this resource group.",
+			},
+		},
+	}
+}
+
+func (r *ProjectListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *ProjectListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+	if !tenancy.RequireClient(r.client, &diags) {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config ProjectListConfigModel
+	diags = req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projects, err := listProjects(ctx, r.client)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Error listing projects: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, p := range projects {
+			if !config.GroupID.IsNull() && (p.GroupID == nil || *p.GroupID != config.GroupID.ValueString()) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = p.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, ProjectIdentityModel{ProjectID: types.StringValue(p.ProjectID)})...)
+			if req.IncludeResource {
+				var state ProjectResourceModel
+				setProjectState(ctx, &state, &p)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listProjects returns every project of the tenant.
+func listProjects(ctx context.Context, client *clients.Client) ([]ProjectAPIResponse, error) {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/assets/v1/project", client.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []ProjectAPIResponse
+	if err := json.Unmarshal(resBody, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
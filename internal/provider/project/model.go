@@ -77,4 +77,9 @@ type S3MultipartCompleteInfo struct {
 type CompleteMultipartUploadRequest struct {
 	Parts    []S3MultipartCompleteInfo `json:"parts"`
 	FileName string                    `json:"file_name"`
-}
\ No newline at end of file
+}
+
+// ProjectIdentityModel maps the resource identity schema used by import blocks.
+type ProjectIdentityModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+}
@@ -5,18 +5,26 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
 )
 
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithIdentity = &ProjectResource{}
+
+// importLookup resolves project:<name> and group:<name>/project:<name> import IDs.
+var importLookup = importid.Collection{Kind: "project", Path: "/assets/v1/project", IDField: "project_id", Parent: &importid.ResourceGroups, ParentField: "group_id"}
 
 func NewProjectResource() resource.Resource {
 	return &ProjectResource{}
@@ -64,7 +72,10 @@ func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				Description: "Type of project (STANDARD, LIBRARY, GENERIC).",
-				Default:     stringdefault.StaticString("STANDARD"),
+				Validators: []validator.String{
+					enums.OneOf(enums.ProjectTypeEnum),
+				},
+				Default: stringdefault.StaticString("STANDARD"),
 			},
 			"last_version_number": schema.Int64Attribute{
 				Computed:    true,
@@ -141,6 +152,17 @@ func (r *ProjectResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *ProjectResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"project_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the project.",
+			},
+		},
+	}
+}
+
 func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("project_id"), req, resp)
-}
\ No newline at end of file
+	importid.ImportState(ctx, r.client, importLookup, path.Root("project_id"), req, resp)
+}
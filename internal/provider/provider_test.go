@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConfigureUnknownTenant(t *testing.T) {
+	ctx := context.Background()
+
+	for _, deferralAllowed := range []bool{true, false} {
+		server := providerserver.NewProtocol6(New("test")())()
+		schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The provider tenant_id is unknown, all other attributes are null.
+		providerType := schemas.Provider.ValueType().(tftypes.Object)
+		vals := map[string]tftypes.Value{}
+		for name, typ := range providerType.AttributeTypes {
+			vals[name] = tftypes.NewValue(typ, nil)
+		}
+		vals["tenant_id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		config, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, vals))
+		if err != nil {
+			t.Fatal(err)
+		}
+		configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+			Config:             &config,
+			ClientCapabilities: &tfprotov6.ConfigureProviderClientCapabilities{DeferralAllowed: deferralAllowed},
+		})
+		if err != nil || len(configured.Diagnostics) > 0 {
+			t.Fatalf("configure: %v %v", err, configured.Diagnostics)
+		}
+
+		usersType := schemas.DataSourceSchemas["sda_users"].ValueType()
+		users, err := tfprotov6.NewDynamicValue(usersType, tftypes.NewValue(usersType, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		read, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+			TypeName:           "sda_users",
+			Config:             &users,
+			ClientCapabilities: &tfprotov6.ReadDataSourceClientCapabilities{DeferralAllowed: deferralAllowed},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if deferralAllowed {
+			if read.Deferred == nil || read.Deferred.Reason != tfprotov6.DeferredReasonProviderConfigUnknown {
+				t.Errorf("with deferral: data source not deferred, diagnostics %v", read.Diagnostics)
+			}
+			continue
+		}
+		if len(read.Diagnostics) != 1 || !strings.Contains(read.Diagnostics[0].Detail, "only known after apply") {
+			t.Errorf("without deferral: diagnostics %v, want the provider not to be configured", read.Diagnostics)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 //-----------------------------------------------------------------
@@ -17,6 +19,10 @@ import (
 //-----------------------------------------------------------------
 
 func (r *ResourceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan ResourceGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -41,7 +47,7 @@ func (r *ResourceGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	url := fmt.Sprintf("%s/assets/v1/resource_group", r.client.HostURL)
 
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
@@ -83,34 +89,29 @@ func (r *ResourceGroupResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	state := ResourceGroupResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		GroupID:           types.StringValue(apiResp.GroupID),
-		Name:              types.StringValue(apiResp.Name),
-		GroupType:         types.StringValue(apiResp.GroupType),
-		ParentGroupID:     types.StringPointerValue(apiResp.ParentGroupID),
-		IsSystemGroup:     types.BoolValue(apiResp.IsSystemGroup),
-	}
+	state := buildResourceGroupState(&apiResp)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ResourceGroupIdentityModel{GroupID: state.GroupID})...)
 }
 
 //-----------------------------------------------------------------
 //         READ
 //-----------------------------------------------------------------
 func (r *ResourceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state ResourceGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ResourceGroupIdentityModel{GroupID: state.GroupID})...)
 
 	url := fmt.Sprintf("%s/assets/v1/resource_group/%s", r.client.HostURL, state.GroupID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
@@ -146,18 +147,7 @@ func (r *ResourceGroupResource) Read(ctx context.Context, req resource.ReadReque
 
 	//apiResp.GroupID = state.GroupID
 
-	state = ResourceGroupResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		GroupID:           types.StringValue(apiResp.GroupID),
-		Name:              types.StringValue(apiResp.Name),
-		GroupType:         types.StringValue(apiResp.GroupType),
-		ParentGroupID:     types.StringPointerValue(apiResp.ParentGroupID),
-		IsSystemGroup:     types.BoolValue(apiResp.IsSystemGroup),
-	}
+	state = buildResourceGroupState(&apiResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -165,6 +155,10 @@ func (r *ResourceGroupResource) Read(ctx context.Context, req resource.ReadReque
 //         UPDATE
 //-----------------------------------------------------------------
 func (r *ResourceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state ResourceGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -191,7 +185,7 @@ func (r *ResourceGroupResource) Update(ctx context.Context, req resource.UpdateR
 
 	url := fmt.Sprintf("%s/assets/v1/resource_group/%s", r.client.HostURL, state.GroupID.ValueString())
 
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
@@ -210,25 +204,19 @@ func (r *ResourceGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	state = ResourceGroupResourceModel{
-		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
-		CreationUserID:    types.StringValue(apiResp.CreationUserID),
-		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
-		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
-		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
-		GroupID:           types.StringValue(apiResp.GroupID),
-		Name:              types.StringValue(apiResp.Name),
-		GroupType:         types.StringValue(apiResp.GroupType),
-		ParentGroupID:     types.StringPointerValue(apiResp.ParentGroupID),
-		IsSystemGroup:     types.BoolValue(apiResp.IsSystemGroup),
-	}
+	state = buildResourceGroupState(&apiResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ResourceGroupIdentityModel{GroupID: state.GroupID})...)
 }
 
 //-----------------------------------------------------------------
 //         DELETE
 //-----------------------------------------------------------------
 func (r *ResourceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state ResourceGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -236,7 +224,7 @@ func (r *ResourceGroupResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 
 	url := fmt.Sprintf("%s/assets/v1/resource_group/%s", r.client.HostURL, state.GroupID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
@@ -252,3 +240,23 @@ func (r *ResourceGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error deleting resource group: %s", err))
 	}
 }
+
+//-----------------------------------------------------------------
+//         HELPER FUNCTIONS
+//-----------------------------------------------------------------
+
+// buildResourceGroupState maps a resource group API response to its Terraform state.
+func buildResourceGroupState(apiResp *ResourceGroupAPIResponse) ResourceGroupResourceModel {
+	return ResourceGroupResourceModel{
+		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
+		CreationUserID:    types.StringValue(apiResp.CreationUserID),
+		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
+		CreationTimestamp: types.StringValue(apiResp.CreationTimestamp),
+		UpdateTimestamp:   types.StringPointerValue(apiResp.UpdateTimestamp),
+		GroupID:           types.StringValue(apiResp.GroupID),
+		Name:              types.StringValue(apiResp.Name),
+		GroupType:         types.StringValue(apiResp.GroupType),
+		ParentGroupID:     types.StringPointerValue(apiResp.ParentGroupID),
+		IsSystemGroup:     types.BoolValue(apiResp.IsSystemGroup),
+	}
+}
@@ -0,0 +1,124 @@
+package resourcegroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var _ list.ListResource = &ResourceGroupListResource{}
+var _ list.ListResourceWithConfigure = &ResourceGroupListResource{}
+
+func NewResourceGroupListResource() list.ListResource {
+	return &ResourceGroupListResource{}
+}
+
+// ResourceGroupListResource enumerates the resource groups of the tenant for `terraform query`.
+type ResourceGroupListResource struct {
+	client *clients.Client
+}
+
+// ResourceGroupListConfigModel maps the list block configuration.
+type ResourceGroupListConfigModel struct {
+	ParentGroupID types.String `tfsdk:"parent_group_id"`
+}
+
+func (r *ResourceGroupListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_group"
+}
+
+func (r *ResourceGroupListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the resource groups of the tenant in the SDA Assets Management Service.",
+		Attributes: map[string]listschema.Attribute{
+			"parent_group_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list resource groups whose parent is this resource group.",
+			},
+		},
+	}
+}
+
+func (r *ResourceGroupListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *ResourceGroupListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+	if !tenancy.RequireClient(r.client, &diags) {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config ResourceGroupListConfigModel
+	diags = req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	groups, err := listResourceGroups(ctx, r.client)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Error listing resource groups: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, g := range groups {
+			if !config.ParentGroupID.IsNull() && (g.ParentGroupID == nil || *g.ParentGroupID != config.ParentGroupID.ValueString()) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = g.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, ResourceGroupIdentityModel{GroupID: types.StringValue(g.GroupID)})...)
+			if req.IncludeResource {
+				state := buildResourceGroupState(&g)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listResourceGroups returns every resource group of the tenant.
+func listResourceGroups(ctx context.Context, client *clients.Client) ([]ResourceGroupAPIResponse, error) {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/assets/v1/resource_group", client.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []ResourceGroupAPIResponse
+	if err := json.Unmarshal(resBody, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
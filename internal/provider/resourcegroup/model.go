@@ -29,3 +29,8 @@ type ResourceGroupAPIResponse struct {
 	ParentGroupID     *string `json:"parent_group_id"`
 	IsSystemGroup     bool    `json:"is_system_group"`
 }
+
+// ResourceGroupIdentityModel maps the resource identity schema used by import blocks.
+type ResourceGroupIdentityModel struct {
+	GroupID types.String `tfsdk:"group_id"`
+}
@@ -5,16 +5,24 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/enums"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
 )
 
 var _ resource.Resource = &ResourceGroupResource{}
 var _ resource.ResourceWithImportState = &ResourceGroupResource{}
+var _ resource.ResourceWithIdentity = &ResourceGroupResource{}
+
+// importLookup resolves group:<name> and group:<parent name>/group:<name> import IDs.
+var importLookup = importid.Collection{Kind: "group", Path: "/assets/v1/resource_group", IDField: "group_id", Parent: &importid.ResourceGroups, ParentField: "parent_group_id"}
 
 func NewResourceGroupResource() resource.Resource {
 	return &ResourceGroupResource{}
@@ -44,6 +52,9 @@ func (r *ResourceGroupResource) Schema(_ context.Context, _ resource.SchemaReque
 				Optional: true,
 				Computed: true,
 				Default:  stringdefault.StaticString("ASSET"),
+				Validators: []validator.String{
+					enums.OneOf(enums.ResourceGroupTypeEnum),
+				},
 			},
 			"parent_group_id": schema.StringAttribute{
 				Optional: true,
@@ -77,6 +88,17 @@ func (r *ResourceGroupResource) Configure(_ context.Context, req resource.Config
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *ResourceGroupResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"group_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the resource group.",
+			},
+		},
+	}
+}
+
 func (r *ResourceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("group_id"), req, resp)
+	importid.ImportState(ctx, r.client, importLookup, path.Root("group_id"), req, resp)
 }
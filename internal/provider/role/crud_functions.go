@@ -10,15 +10,26 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 // CREATE
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan RoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, plan.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	payload := map[string]interface{}{
 		"name": plan.Name.ValueString(),
@@ -31,25 +42,13 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		payload["description"] = plan.Description.ValueString()
 	}
 
-	// policies (list of strings representing policy ids or JSON)
-	if !plan.Policies.IsUnknown() && !plan.Policies.IsNull() {
-		var policyStrings []string
-		if diags := plan.Policies.ElementsAs(ctx, &policyStrings, false); diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return
-		}
-
-		// Unmarshal each JSON string back into a map or object
-		var policyObjects []map[string]interface{}
-		for _, policyStr := range policyStrings {
-			var policyMap map[string]interface{}
-			if err := json.Unmarshal([]byte(policyStr), &policyMap); err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error unmarshalling policy JSON string: %s. Content: %s", err, policyStr))
-				return
-			}
-			policyObjects = append(policyObjects, policyMap)
-		}
-		payload["policies"] = policyObjects
+	policies, diags := expandPolicies(ctx, plan.Policies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(policies) > 0 {
+		payload["policies"] = policies
 	}
 
 	if !plan.SsoGroupMapping.IsUnknown() && !plan.SsoGroupMapping.IsNull() {
@@ -68,15 +67,15 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	url := fmt.Sprintf("%s/ident/v1/user_role", r.client.HostURL)
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	url := fmt.Sprintf("%s/ident/v1/user_role", client.HostURL)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
 	}
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
-	resBody, err := r.client.DoRequest(reqHTTP, nil)
+	resBody, err := client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		errSummary := "API Error"
 		if strings.Contains(err.Error(), "status: 403") {
@@ -99,6 +98,7 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	state := RoleResourceModel{
+		TenantID:          plan.TenantID,
 		ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
 		CreationUserID:    types.StringValue(apiResp.CreationUserID),
 		UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
@@ -112,32 +112,50 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Preserve provided lists in state
-	if !plan.Policies.IsUnknown() {
-		state.Policies = plan.Policies
-	}
+	state.Policies = plan.Policies
 	if !plan.SsoGroupMapping.IsUnknown() {
 		state.SsoGroupMapping = plan.SsoGroupMapping
+	} else {
+		// Not configured: record the mappings the API reports, which
+		// sda_role_sso_mapping resources may add to later.
+		ssoList, diags := types.ListValueFrom(ctx, types.StringType, apiResp.SsoGroupMapping)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.SsoGroupMapping = ssoList
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleIdentityModel{UserRoleID: state.UserRoleID, TenantID: state.TenantID})...)
 }
 
 // READ
 func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state RoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleIdentityModel{UserRoleID: state.UserRoleID, TenantID: state.TenantID})...)
 
-	url := fmt.Sprintf("%s/ident/v1/user_role/%s", r.client.HostURL, state.UserRoleID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	url := fmt.Sprintf("%s/ident/v1/user_role/%s", client.HostURL, state.UserRoleID.ValueString())
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
 	}
 
-	resBody, err := r.client.DoRequest(reqHTTP, nil)
+	resBody, err := client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "status: 404") {
 			resp.State.RemoveResource(ctx)
@@ -176,50 +194,12 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.IsSystemRole = types.BoolValue(apiResp.IsSystemRole)
 
 	// Read policies from API response
-	if apiResp.Policies != nil {
-		var policyStrings []string
-		for _, p := range apiResp.Policies {
-			policyJSON, err := json.Marshal(p)
-			if err != nil {
-				resp.Diagnostics.AddError("Decode Error",
-					fmt.Sprintf("Error marshalling policy: %s", err))
-				return
-			}
-
-			// Strip server-generated fields that aren't in user's config
-			var policyMap map[string]interface{}
-			if err := json.Unmarshal(policyJSON, &policyMap); err != nil {
-				resp.Diagnostics.AddError("Decode Error",
-					fmt.Sprintf("Error unmarshalling policy: %s", err))
-				return
-			}
-			delete(policyMap, "policy_id")
-
-			// Remove null description to prevent perpetual diff
-			if desc, ok := policyMap["description"]; ok {
-				if desc == nil || desc == "" {
-					delete(policyMap, "description")
-				}
-			}
-
-			cleanJSON, err := json.Marshal(policyMap)
-			if err != nil {
-				resp.Diagnostics.AddError("Decode Error",
-					fmt.Sprintf("Error marshalling cleaned policy: %s", err))
-				return
-			}
-										
-			policyStrings = append(policyStrings, string(cleanJSON))
-		}
-		policyList, diags := types.ListValueFrom(ctx, types.StringType, policyStrings)
-		if diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return
-		}
-		state.Policies = policyList
-	} else {
-		state.Policies = types.ListNull(types.StringType)
+	policySet, diags := flattenPolicies(ctx, apiResp.Policies)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
 	}
+	state.Policies = policySet
 
 	// Read SSO group mapping from API response
 	if apiResp.SsoGroupMapping != nil {
@@ -237,12 +217,21 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // UPDATE
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state RoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, plan.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	payload := map[string]interface{}{
 		"object_version": state.ObjectVersion.ValueInt64(),
@@ -267,39 +256,23 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	if !plan.Policies.Equal(state.Policies) {
-		if plan.Policies.IsNull() {
-			payload["policies"] = []map[string]interface{}{} // Send empty object list
-		} else {
-			var policyStrings []string
-			if diags := plan.Policies.ElementsAs(ctx, &policyStrings, false); diags.HasError() {
-				resp.Diagnostics.Append(diags...)
-				return
-			}
-			// Unmarshal each JSON string back into a map or object
-			var policyObjects []map[string]interface{}
-			for _, policyStr := range policyStrings {
-				var policyMap map[string]interface{}
-				if err := json.Unmarshal([]byte(policyStr), &policyMap); err != nil {
-					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error unmarshalling policy JSON string: %s. Content: %s", err, policyStr))
-					return
-				}
-				policyObjects = append(policyObjects, policyMap)
-			}
-			payload["policies"] = policyObjects
+		policies, diags := expandPolicies(ctx, plan.Policies)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		payload["policies"] = policies
 	}
 
-	if !plan.SsoGroupMapping.Equal(state.SsoGroupMapping) {
-		if plan.SsoGroupMapping.IsNull() {
-			payload["sso_group_mapping"] = []string{}
-		} else {
-			var sso []string
-			if diags := plan.SsoGroupMapping.ElementsAs(ctx, &sso, false); diags.HasError() {
-				resp.Diagnostics.Append(diags...)
-				return
-			}
-			payload["sso_group_mapping"] = sso
+	// An unset sso_group_mapping follows the state, so that removing it from
+	// the configuration leaves the mappings to sda_role_sso_mapping resources.
+	if !plan.SsoGroupMapping.IsUnknown() && !plan.SsoGroupMapping.Equal(state.SsoGroupMapping) {
+		var sso []string
+		if diags := plan.SsoGroupMapping.ElementsAs(ctx, &sso, false); diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
 		}
+		payload["sso_group_mapping"] = sso
 	}
 
 	body, err := json.Marshal(payload)
@@ -308,15 +281,15 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	url := fmt.Sprintf("%s/ident/v1/user_role/%s", r.client.HostURL, state.UserRoleID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	url := fmt.Sprintf("%s/ident/v1/user_role/%s", client.HostURL, state.UserRoleID.ValueString())
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
 	}
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
-	resBody, err := r.client.DoRequest(reqHTTP, nil)
+	resBody, err := client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error updating role: %s\nResponse body:\n%s", err, string(resBody)))
 		return
@@ -349,33 +322,48 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	state.IsSystemRole = types.BoolValue(apiResp.IsSystemRole)
 
 	// Preserve plan policies and sso_group_mapping in state to avoid inconsistent results
-	if !plan.Policies.IsNull() && !plan.Policies.IsUnknown() {
-		state.Policies = plan.Policies
-	}
+	state.Policies = plan.Policies
 
 	if !plan.SsoGroupMapping.IsNull() && !plan.SsoGroupMapping.IsUnknown() {
 		state.SsoGroupMapping = plan.SsoGroupMapping
+	} else if plan.SsoGroupMapping.IsUnknown() {
+		ssoList, diags := types.ListValueFrom(ctx, types.StringType, apiResp.SsoGroupMapping)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.SsoGroupMapping = ssoList
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleIdentityModel{UserRoleID: state.UserRoleID, TenantID: state.TenantID})...)
 }
 
 // DELETE
 func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state RoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	url := fmt.Sprintf("%s/ident/v1/user_role/%s", r.client.HostURL, state.UserRoleID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	url := fmt.Sprintf("%s/ident/v1/user_role/%s", client.HostURL, state.UserRoleID.ValueString())
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
 	}
 
-	_, err = r.client.DoRequest(reqHTTP, nil)
+	_, err = client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "status: 404") {
 			return
@@ -5,11 +5,12 @@ import (
 )
 
 type RoleResourceModel struct {
+    TenantID         types.String `tfsdk:"tenant_id"`
     UserRoleID       types.String `tfsdk:"user_role_id"`
     Name             types.String `tfsdk:"name"`
     GroupID          types.String `tfsdk:"group_id"`
     Description      types.String `tfsdk:"description"`
-    Policies         types.List   `tfsdk:"policies"`
+    Policies         types.Set    `tfsdk:"policy"`
     IsSystemRole     types.Bool   `tfsdk:"is_system_role"`
     SsoGroupMapping  types.List   `tfsdk:"sso_group_mapping"`
     ObjectVersion    types.Int64  `tfsdk:"object_version"`
@@ -20,7 +21,7 @@ type RoleResourceModel struct {
 }
 
 type Policy struct {
-    PolicyID   string   `json:"policy_id"`
+    PolicyID   string   `json:"policy_id,omitempty"`
     Name       string   `json:"name"`
     Action     []string `json:"action"`
     Resource   []string `json:"resource"`
@@ -43,3 +44,9 @@ type CreateRoleAPIResponse struct {
 }
 
 type RoleAPIResponse = CreateRoleAPIResponse
+
+// RoleIdentityModel maps the resource identity schema used by import blocks.
+type RoleIdentityModel struct {
+    UserRoleID types.String `tfsdk:"user_role_id"`
+    TenantID   types.String `tfsdk:"tenant_id"`
+}
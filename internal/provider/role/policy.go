@@ -0,0 +1,107 @@
+package role
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ActionPattern matches policy actions: "*" or <service>:<verb>, where the
+// verb may contain "*" wildcards, for example "assets:Read*".
+var ActionPattern = regexp.MustCompile(`^(\*|[a-z][a-z0-9_-]*:[A-Za-z0-9_*]+)$`)
+
+// ResourcePattern matches policy resources: "*" or <service>:<type>[/<id>...],
+// where each part may contain "*" wildcards, for example
+// "assets:resource_group/*" or "assets:resource_group/g-1/device/*".
+var ResourcePattern = regexp.MustCompile(`^(\*|[a-z][a-z0-9_-]*:[a-z0-9_*]+(/[A-Za-z0-9_.:*-]+)*)$`)
+
+// PolicyModel maps a policy block of the sda_role resource.
+type PolicyModel struct {
+	Name        types.String `tfsdk:"name"`
+	Actions     types.Set    `tfsdk:"actions"`
+	Resources   types.Set    `tfsdk:"resources"`
+	Description types.String `tfsdk:"description"`
+}
+
+// policyAttrTypes are the attribute types of a policy block object.
+var policyAttrTypes = map[string]attr.Type{
+	"name":        types.StringType,
+	"actions":     types.SetType{ElemType: types.StringType},
+	"resources":   types.SetType{ElemType: types.StringType},
+	"description": types.StringType,
+}
+
+// policyObjectType is the element type of the policy block set.
+var policyObjectType = types.ObjectType{AttrTypes: policyAttrTypes}
+
+// expandPolicies converts the policy blocks into the API representation. The
+// server assigned policy_id is not managed by Terraform and is left empty.
+func expandPolicies(ctx context.Context, set types.Set) ([]Policy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	policies := []Policy{}
+	if set.IsNull() || set.IsUnknown() {
+		return policies, diags
+	}
+
+	var models []PolicyModel
+	diags.Append(set.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, m := range models {
+		p := Policy{
+			Name:        m.Name.ValueString(),
+			Action:      []string{},
+			Resource:    []string{},
+			Description: m.Description.ValueStringPointer(),
+		}
+		diags.Append(m.Actions.ElementsAs(ctx, &p.Action, false)...)
+		diags.Append(m.Resources.ElementsAs(ctx, &p.Resource, false)...)
+		policies = append(policies, p)
+	}
+	return policies, diags
+}
+
+// flattenPolicies converts API policies into the policy block set. Actions and
+// resources become sets so that the order returned by the API never causes a
+// diff, and an empty description is stored as null.
+func flattenPolicies(ctx context.Context, policies []Policy) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	models := make([]PolicyModel, 0, len(policies))
+	for _, p := range policies {
+		actions, d := types.SetValueFrom(ctx, types.StringType, nonNil(p.Action))
+		diags.Append(d...)
+		resources, d := types.SetValueFrom(ctx, types.StringType, nonNil(p.Resource))
+		diags.Append(d...)
+
+		description := types.StringNull()
+		if p.Description != nil && *p.Description != "" {
+			description = types.StringValue(*p.Description)
+		}
+
+		models = append(models, PolicyModel{
+			Name:        types.StringValue(p.Name),
+			Actions:     actions,
+			Resources:   resources,
+			Description: description,
+		})
+	}
+	if diags.HasError() {
+		return types.SetNull(policyObjectType), diags
+	}
+
+	set, d := types.SetValueFrom(ctx, policyObjectType, models)
+	diags.Append(d...)
+	return set, diags
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,117 @@
+package role
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPolicyPatterns(t *testing.T) {
+	actions := map[string]bool{
+		"*":                  true,
+		"assets:ReadDevice":  true,
+		"assets:Read*":       true,
+		"ident:*":            true,
+		"ReadDevice":         false,
+		"assets:":            false,
+		"assets:read device": false,
+		"assets:Read/Device": false,
+		"Assets:Read":        false,
+	}
+	for action, want := range actions {
+		if got := ActionPattern.MatchString(action); got != want {
+			t.Errorf("ActionPattern.MatchString(%q) = %v, want %v", action, got, want)
+		}
+	}
+
+	resources := map[string]bool{
+		"*":                                  true,
+		"assets:*":                           true,
+		"assets:device":                      true,
+		"assets:resource_group/*":            true,
+		"assets:resource_group/g-1/device/*": true,
+		"assets:device/3f2a.9c:1":            true,
+		"resource_group/*":                   false,
+		"assets:":                            false,
+		"assets:/g-1":                        false,
+		"assets:device/":                     false,
+		"assets:device//d-1":                 false,
+		"assets:Device/*":                    false,
+		"assets:device name":                 false,
+	}
+	for resource, want := range resources {
+		if got := ResourcePattern.MatchString(resource); got != want {
+			t.Errorf("ResourcePattern.MatchString(%q) = %v, want %v", resource, got, want)
+		}
+	}
+}
+
+func TestPoliciesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	description := "read devices"
+	empty := ""
+	api := []Policy{
+		{PolicyID: "p-1", Name: "read", Action: []string{"assets:Read*", "assets:List*"}, Resource: []string{"assets:device/*"}, Description: &description},
+		{PolicyID: "p-2", Name: "all", Action: []string{"*"}, Resource: []string{"*"}, Description: &empty},
+	}
+
+	set, diags := flattenPolicies(ctx, api)
+	if diags.HasError() {
+		t.Fatalf("flattenPolicies: %v", diags)
+	}
+
+	// The API may return policies and their lists in any order.
+	reordered := []Policy{
+		{PolicyID: "p-2", Name: "all", Action: []string{"*"}, Resource: []string{"*"}},
+		{PolicyID: "p-1", Name: "read", Action: []string{"assets:List*", "assets:Read*"}, Resource: []string{"assets:device/*"}, Description: &description},
+	}
+	other, diags := flattenPolicies(ctx, reordered)
+	if diags.HasError() {
+		t.Fatalf("flattenPolicies: %v", diags)
+	}
+	if !set.Equal(other) {
+		t.Fatalf("reordered policies differ:\n%s\n%s", set, other)
+	}
+
+	policies, diags := expandPolicies(ctx, set)
+	if diags.HasError() {
+		t.Fatalf("expandPolicies: %v", diags)
+	}
+	if len(policies) != 2 {
+		t.Fatalf("expected 2 policies, got %d", len(policies))
+	}
+	for _, p := range policies {
+		if p.PolicyID != "" {
+			t.Errorf("policy %s: policy_id should not be sent, got %q", p.Name, p.PolicyID)
+		}
+		switch p.Name {
+		case "read":
+			if len(p.Action) != 2 || p.Description == nil || *p.Description != description {
+				t.Errorf("unexpected read policy: %+v", p)
+			}
+		case "all":
+			if p.Description != nil {
+				t.Errorf("empty description should be null, got %q", *p.Description)
+			}
+		default:
+			t.Errorf("unexpected policy %q", p.Name)
+		}
+	}
+
+	again, diags := flattenPolicies(ctx, policies)
+	if diags.HasError() {
+		t.Fatalf("flattenPolicies: %v", diags)
+	}
+	if !set.Equal(again) {
+		t.Fatalf("policies drifted after round trip:\n%s\n%s", set, again)
+	}
+}
+
+func TestFlattenPoliciesEmpty(t *testing.T) {
+	set, diags := flattenPolicies(context.Background(), nil)
+	if diags.HasError() {
+		t.Fatalf("flattenPolicies: %v", diags)
+	}
+	if set.IsNull() || len(set.Elements()) != 0 {
+		t.Fatalf("expected an empty set, got %s", set)
+	}
+}
@@ -3,18 +3,27 @@ package role
 import (
     "context"
 
+    "github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
     "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
     "github.com/hashicorp/terraform-plugin-framework/types"
 
     "github.com/sda/terraform-provider-sda/internal/clients"
+    "github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+    "github.com/sda/terraform-provider-sda/internal/provider/validators"
 )
 
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithIdentity = &RoleResource{}
 
 func NewRoleResource() resource.Resource {
     return &RoleResource{}
@@ -32,6 +41,7 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
     resp.Schema = schema.Schema{
         Description: "Manages a user role resource in the SDA Ident Service.",
         Attributes: map[string]schema.Attribute{
+            "tenant_id": tenancy.Attribute(),
             "user_role_id": schema.StringAttribute{
                 Computed:    true,
                 Description: "Unique identifier for the user role.",
@@ -54,11 +64,6 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
                 Optional:    true,
                 Description: "Description of the role.",
             },
-            "policies": schema.ListAttribute{
-                ElementType: types.StringType,
-                Optional:    true,
-                Description: "List of policies for the role (string representation).",
-            },
             "is_system_role": schema.BoolAttribute{
                 Computed:    true,
                 Description: "Whether this is a system role.",
@@ -66,7 +71,15 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
             "sso_group_mapping": schema.ListAttribute{
                 ElementType: types.StringType,
                 Optional:    true,
-                Description: "SSO group mapping list.",
+                Computed:    true,
+                Description: "Identity provider (IdP) groups whose members receive the role. When set, the list is managed authoritatively and `[]` removes all mappings; leave it unset when the mappings of the role are managed with `sda_role_sso_mapping` resources, and do not combine both for the same role. Removing the attribute from the configuration keeps the current mappings.",
+                PlanModifiers: []planmodifier.List{
+                    listplanmodifier.UseStateForUnknown(),
+                },
+                Validators: []validator.List{
+                    listvalidator.UniqueValues(),
+                    listvalidator.ValueStringsAre(validators.SSOGroupName()),
+                },
             },
             "object_version": schema.Int64Attribute{
                 Computed:    true,
@@ -89,6 +102,41 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
                 Description: "Date and time when this object was last modified (ISO 8601).",
             },
         },
+        Blocks: map[string]schema.Block{
+            "policy": schema.SetNestedBlock{
+                Description: "Policy granted by the role. Policies, actions and resources are compared regardless of order.",
+                NestedObject: schema.NestedBlockObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Required:    true,
+                            Description: "Name of the policy.",
+                        },
+                        "actions": schema.SetAttribute{
+                            ElementType: types.StringType,
+                            Required:    true,
+                            Description: "Actions allowed by the policy, either `*` or `<service>:<verb>`, for example `assets:Read*`. `*` matches any characters.",
+                            Validators: []validator.Set{
+                                setvalidator.SizeAtLeast(1),
+                                setvalidator.ValueStringsAre(stringvalidator.RegexMatches(ActionPattern, "must be * or <service>:<verb>")),
+                            },
+                        },
+                        "resources": schema.SetAttribute{
+                            ElementType: types.StringType,
+                            Required:    true,
+                            Description: "Resources the actions apply to, either `*` or `<service>:<type>/<id>`, for example `assets:resource_group/*`. `*` matches any characters.",
+                            Validators: []validator.Set{
+                                setvalidator.SizeAtLeast(1),
+                                setvalidator.ValueStringsAre(stringvalidator.RegexMatches(ResourcePattern, "must be * or <service>:<type>/<id>")),
+                            },
+                        },
+                        "description": schema.StringAttribute{
+                            Optional:    true,
+                            Description: "Description of the policy.",
+                        },
+                    },
+                },
+            },
+        },
     }
 }
 
@@ -99,6 +147,18 @@ func (r *RoleResource) Configure(_ context.Context, req resource.ConfigureReques
     r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *RoleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+    resp.IdentitySchema = identityschema.Schema{
+        Attributes: map[string]identityschema.Attribute{
+            "tenant_id": tenancy.IdentityAttribute(),
+            "user_role_id": identityschema.StringAttribute{
+                RequiredForImport: true,
+                Description:       "Unique identifier for the user role.",
+            },
+        },
+    }
+}
+
 func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-    resource.ImportStatePassthroughID(ctx, path.Root("user_role_id"), req, resp)
+    tenancy.ImportStatePassthrough(ctx, path.Root("user_role_id"), req, resp)
 }
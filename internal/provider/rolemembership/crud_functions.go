@@ -0,0 +1,235 @@
+package rolemembership
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/role"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+	"github.com/sda/terraform-provider-sda/internal/provider/user"
+)
+
+// CREATE
+func (r *RoleMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RoleMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleMembershipIdentityModel{UserRoleID: plan.UserRoleID, TenantID: plan.TenantID})...)
+}
+
+// READ
+func (r *RoleMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state RoleMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleMembershipIdentityModel{UserRoleID: state.UserRoleID, TenantID: state.TenantID})...)
+
+	members, err := ListRoleMembers(ctx, client, state.UserRoleID.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "status: 404") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading members of role %s: %s", state.UserRoleID.ValueString(), err))
+		return
+	}
+
+	// Members granted outside of Terraform show up as drift here.
+	userIDs, diags := types.SetValueFrom(ctx, types.StringType, memberIDs(members))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.UserIDs = userIDs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// UPDATE
+func (r *RoleMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RoleMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleMembershipIdentityModel{UserRoleID: plan.UserRoleID, TenantID: plan.TenantID})...)
+}
+
+// DELETE
+func (r *RoleMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoleMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.UserIDs = types.SetValueMust(types.StringType, nil)
+	resp.Diagnostics.Append(r.reconcile(ctx, state)...)
+}
+
+// HELPER FUNCTIONS
+
+// reconcile assigns the role to every user in m.UserIDs and removes it from
+// every other user that currently holds it.
+func (r *RoleMembershipResource) reconcile(ctx context.Context, m RoleMembershipResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	roleID := m.UserRoleID.ValueString()
+
+	client, clientDiags := tenancy.Client(r.client, m.TenantID)
+	diags.Append(clientDiags...)
+	var desired []string
+	diags.Append(m.UserIDs.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	members, err := ListRoleMembers(ctx, client, roleID)
+	if err != nil {
+		// Nothing to remove once the role itself is gone.
+		if len(desired) == 0 && strings.Contains(err.Error(), "status: 404") {
+			return diags
+		}
+		diags.AddError("API Error", fmt.Sprintf("Error reading members of role %s: %s", roleID, err))
+		return diags
+	}
+
+	add, remove := diff(desired, memberIDs(members))
+	for _, userID := range add {
+		tflog.Debug(ctx, "Adding role member", map[string]any{"user_role_id": roleID, "user_id": userID})
+		if err := setMember(ctx, client, http.MethodPost, roleID, userID); err != nil {
+			diags.AddError("API Error", fmt.Sprintf("Error assigning role %s to user %s: %s", roleID, userID, err))
+			return diags
+		}
+	}
+	for _, userID := range remove {
+		tflog.Debug(ctx, "Removing role member", map[string]any{"user_role_id": roleID, "user_id": userID})
+		if err := setMember(ctx, client, http.MethodDelete, roleID, userID); err != nil && !strings.Contains(err.Error(), "status: 404") {
+			diags.AddError("API Error", fmt.Sprintf("Error removing role %s from user %s: %s", roleID, userID, err))
+			return diags
+		}
+	}
+	return diags
+}
+
+// setMember creates (POST) or deletes (DELETE) the link between a user and a role.
+func setMember(ctx context.Context, client *clients.Client, method, roleID, userID string) error {
+	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", client.HostURL, userID, roleID)
+
+	var body io.Reader
+	if method == http.MethodPost {
+		body = strings.NewReader("{}")
+	}
+
+	reqHTTP, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if method == http.MethodPost {
+		reqHTTP.Header.Set("Content-Type", "application/json")
+	}
+
+	_, err = client.DoRequest(reqHTTP, nil)
+	return err
+}
+
+// ListRoleMembers returns the users that hold the role roleID.
+func ListRoleMembers(ctx context.Context, client *clients.Client, roleID string) ([]user.UserAPIResponse, error) {
+	var users []user.UserAPIResponse
+	err := getJSON(ctx, client, fmt.Sprintf("%s/ident/v1/user_role/%s/user", client.HostURL, roleID), &users)
+	return users, err
+}
+
+// ListUserRoles returns the roles held by the user userID.
+func ListUserRoles(ctx context.Context, client *clients.Client, userID string) ([]role.RoleAPIResponse, error) {
+	var roles []role.RoleAPIResponse
+	err := getJSON(ctx, client, fmt.Sprintf("%s/ident/v1/user/%s/user_role", client.HostURL, userID), &roles)
+	return roles, err
+}
+
+func getJSON(ctx context.Context, client *clients.Client, url string, v any) error {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resBody, v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+func memberIDs(users []user.UserAPIResponse) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// diff returns the IDs in desired but not in current, and the IDs in current
+// but not in desired, both sorted.
+func diff(desired, current []string) (add, remove []string) {
+	want := map[string]bool{}
+	for _, id := range desired {
+		want[id] = true
+	}
+	have := map[string]bool{}
+	for _, id := range current {
+		have[id] = true
+		if !want[id] {
+			remove = append(remove, id)
+		}
+	}
+	for id := range want {
+		if !have[id] {
+			add = append(add, id)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
@@ -0,0 +1,60 @@
+package rolemembership
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/ident/v1/user_role/r1/user" {
+			// u1 is declared, u3 was granted manually in the UI.
+			w.Write([]byte(`[
+				{"user_id": "u1", "first_name": "A", "last_name": "A", "email": "a@example.com", "source": "SDA"},
+				{"user_id": "u3", "first_name": "C", "last_name": "C", "email": "c@example.com", "source": "SAML"}
+			]`))
+			return
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	r := &RoleMembershipResource{client: &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}}
+	m := RoleMembershipResourceModel{
+		UserRoleID: types.StringValue("r1"),
+		UserIDs:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("u1"), types.StringValue("u2")}),
+	}
+
+	if diags := r.reconcile(ctx, m); diags.HasError() {
+		t.Fatalf("reconcile: %v", diags)
+	}
+
+	sort.Strings(calls)
+	want := []string{
+		"DELETE /ident/v1/user_role_user_link/user/u3/user_role/r1",
+		"POST /ident/v1/user_role_user_link/user/u2/user_role/r1",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	add, remove := diff([]string{"b", "a", "c"}, []string{"c", "d", "a"})
+	if !reflect.DeepEqual(add, []string{"b"}) || !reflect.DeepEqual(remove, []string{"d"}) {
+		t.Fatalf("diff = %v, %v", add, remove)
+	}
+}
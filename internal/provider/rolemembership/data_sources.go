@@ -0,0 +1,209 @@
+package rolemembership
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var (
+	_ datasource.DataSource              = &RoleMembersDataSource{}
+	_ datasource.DataSourceWithConfigure = &RoleMembersDataSource{}
+	_ datasource.DataSource              = &UserRolesDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserRolesDataSource{}
+)
+
+func NewRoleMembersDataSource() datasource.DataSource {
+	return &RoleMembersDataSource{}
+}
+
+func NewUserRolesDataSource() datasource.DataSource {
+	return &UserRolesDataSource{}
+}
+
+// RoleMembersDataSource lists the users that hold a role.
+type RoleMembersDataSource struct {
+	client *clients.Client
+}
+
+// UserRolesDataSource lists the roles held by a user.
+type UserRolesDataSource struct {
+	client *clients.Client
+}
+
+func (d *RoleMembersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_members"
+}
+
+func (d *RoleMembersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the users that hold a user role in the SDA Ident Service.",
+		Attributes: map[string]schema.Attribute{
+			"user_role_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Unique identifier for the user role.",
+			},
+			"user_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Sorted unique identifiers of the users that hold the role.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Users that hold the role, sorted by user_id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id":    schema.StringAttribute{Computed: true, Description: "Unique identifier for the user."},
+						"email":      schema.StringAttribute{Computed: true, Description: "Email address of the user."},
+						"first_name": schema.StringAttribute{Computed: true, Description: "First name of the user."},
+						"last_name":  schema.StringAttribute{Computed: true, Description: "Last name of the user."},
+						"group_id":   schema.StringAttribute{Computed: true, Description: "Group id of the user."},
+						"source":     schema.StringAttribute{Computed: true, Description: "Source of the user, `SDA` or `SAML`."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RoleMembersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureClient(req, resp)
+}
+
+func (d *RoleMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state RoleMembersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := ListRoleMembers(ctx, d.client, state.UserRoleID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading members of role %s: %s", state.UserRoleID.ValueString(), err))
+		return
+	}
+
+	state.UserIDs = memberIDs(members)
+	byID := map[string]MemberUserModel{}
+	for _, u := range members {
+		byID[u.UserID] = MemberUserModel{
+			UserID:    types.StringValue(u.UserID),
+			Email:     types.StringValue(u.Email),
+			FirstName: types.StringValue(u.FirstName),
+			LastName:  types.StringValue(u.LastName),
+			GroupID:   types.StringPointerValue(u.GroupID),
+			Source:    types.StringValue(u.Source),
+		}
+	}
+	state.Users = make([]MemberUserModel, 0, len(state.UserIDs))
+	for _, id := range state.UserIDs {
+		state.Users = append(state.Users, byID[id])
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *UserRolesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_roles"
+}
+
+func (d *UserRolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the user roles held by a user in the SDA Ident Service.",
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Unique identifier for the user.",
+			},
+			"user_role_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Sorted unique identifiers of the roles held by the user.",
+			},
+			"roles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Roles held by the user, sorted by user_role_id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_role_id":   schema.StringAttribute{Computed: true, Description: "Unique identifier for the user role."},
+						"name":           schema.StringAttribute{Computed: true, Description: "Name of the user role."},
+						"group_id":       schema.StringAttribute{Computed: true, Description: "Group id of the role."},
+						"description":    schema.StringAttribute{Computed: true, Description: "Description of the role."},
+						"is_system_role": schema.BoolAttribute{Computed: true, Description: "Whether this is a system role."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UserRolesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureClient(req, resp)
+}
+
+func (d *UserRolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state UserRolesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, err := ListUserRoles(ctx, d.client, state.UserID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading roles of user %s: %s", state.UserID.ValueString(), err))
+		return
+	}
+
+	state.UserRoleIDs = make([]string, 0, len(roles))
+	state.Roles = make([]UserRoleModel, 0, len(roles))
+	for _, r := range roles {
+		state.Roles = append(state.Roles, UserRoleModel{
+			UserRoleID:   types.StringValue(r.UserRoleID),
+			Name:         types.StringValue(r.Name),
+			GroupID:      types.StringPointerValue(r.GroupID),
+			Description:  types.StringPointerValue(r.Description),
+			IsSystemRole: types.BoolValue(r.IsSystemRole),
+		})
+	}
+	sort.Slice(state.Roles, func(i, j int) bool {
+		return state.Roles[i].UserRoleID.ValueString() < state.Roles[j].UserRoleID.ValueString()
+	})
+	for _, r := range state.Roles {
+		state.UserRoleIDs = append(state.UserRoleIDs, r.UserRoleID.ValueString())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// configureClient returns the provider configured client of a data source.
+func configureClient(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *clients.Client {
+	// ProviderData is nil until the provider has been configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+	return client
+}
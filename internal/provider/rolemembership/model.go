@@ -0,0 +1,46 @@
+package rolemembership
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RoleMembershipResourceModel struct {
+	TenantID   types.String `tfsdk:"tenant_id"`
+	UserRoleID types.String `tfsdk:"user_role_id"`
+	UserIDs    types.Set    `tfsdk:"user_ids"`
+}
+
+// RoleMembershipIdentityModel maps the resource identity schema used by import blocks.
+type RoleMembershipIdentityModel struct {
+	UserRoleID types.String `tfsdk:"user_role_id"`
+	TenantID   types.String `tfsdk:"tenant_id"`
+}
+
+type RoleMembersDataSourceModel struct {
+	UserRoleID types.String      `tfsdk:"user_role_id"`
+	UserIDs    []string          `tfsdk:"user_ids"`
+	Users      []MemberUserModel `tfsdk:"users"`
+}
+
+type MemberUserModel struct {
+	UserID    types.String `tfsdk:"user_id"`
+	Email     types.String `tfsdk:"email"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+	GroupID   types.String `tfsdk:"group_id"`
+	Source    types.String `tfsdk:"source"`
+}
+
+type UserRolesDataSourceModel struct {
+	UserID      types.String    `tfsdk:"user_id"`
+	UserRoleIDs []string        `tfsdk:"user_role_ids"`
+	Roles       []UserRoleModel `tfsdk:"roles"`
+}
+
+type UserRoleModel struct {
+	UserRoleID   types.String `tfsdk:"user_role_id"`
+	Name         types.String `tfsdk:"name"`
+	GroupID      types.String `tfsdk:"group_id"`
+	Description  types.String `tfsdk:"description"`
+	IsSystemRole types.Bool   `tfsdk:"is_system_role"`
+}
@@ -0,0 +1,76 @@
+package rolemembership
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var _ resource.Resource = &RoleMembershipResource{}
+var _ resource.ResourceWithImportState = &RoleMembershipResource{}
+var _ resource.ResourceWithIdentity = &RoleMembershipResource{}
+
+func NewRoleMembershipResource() resource.Resource {
+	return &RoleMembershipResource{}
+}
+
+type RoleMembershipResource struct {
+	client *clients.Client
+}
+
+func (r *RoleMembershipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_membership"
+}
+
+func (r *RoleMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Authoritatively manages the members of a user role in the SDA Ident Service. Users assigned to the role outside of this resource are removed on the next apply, so do not combine it with `sda_user_role_association` for the same role.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": tenancy.Attribute(),
+			"user_role_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Unique identifier for the user role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Unique identifiers of all users that hold the role. An empty set removes every member.",
+			},
+		},
+	}
+}
+
+func (r *RoleMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *RoleMembershipResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"tenant_id": tenancy.IdentityAttribute(),
+			"user_role_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the user role.",
+			},
+		},
+	}
+}
+
+func (r *RoleMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tenancy.ImportStatePassthrough(ctx, path.Root("user_role_id"), req, resp)
+}
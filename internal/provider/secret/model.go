@@ -33,3 +33,8 @@ type SecretAPIResponse struct {
 	Value             *string `json:"secret_value"`
 	Type              string  `json:"secret_type"`
 }
+
+// SecretIdentityModel maps the resource identity schema used by import blocks.
+type SecretIdentityModel struct {
+	SecretID types.String `tfsdk:"secret_id"`
+}
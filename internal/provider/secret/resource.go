@@ -5,15 +5,21 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
 )
 
 var _ resource.Resource = &SecretResource{}
 var _ resource.ResourceWithImportState = &SecretResource{}
+var _ resource.ResourceWithIdentity = &SecretResource{}
+
+// importLookup resolves secret:<name> and vault:<name>/secret:<name> import IDs.
+var importLookup = importid.Collection{Kind: "secret", Path: "/assets/v1/secret", IDField: "secret_id", Parent: &importid.Vaults, ParentField: "vault_id"}
 
 func NewSecretResource() resource.Resource {
 	return &SecretResource{}
@@ -93,6 +99,17 @@ func (r *SecretResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *SecretResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"secret_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the secret.",
+			},
+		},
+	}
+}
+
 func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("secret_id"), req, resp)
+	importid.ImportState(ctx, r.client, importLookup, path.Root("secret_id"), req, resp)
 }
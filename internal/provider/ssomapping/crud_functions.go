@@ -0,0 +1,125 @@
+package ssomapping
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+// CREATE
+func (r *RoleSSOMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan RoleSSOMappingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(r.client, plan.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	roleID, group := plan.UserRoleID.ValueString(), plan.SSOGroup.ValueString()
+
+	err := UpdateSSOGroups(ctx, client, roleID, func(groups []string) []string {
+		if slices.Contains(groups, group) {
+			return groups
+		}
+		return append(groups, group)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error mapping IdP group %q to role %s: %s", group, roleID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleSSOMappingIdentityModel{UserRoleID: plan.UserRoleID, SSOGroup: plan.SSOGroup, TenantID: plan.TenantID})...)
+}
+
+// READ
+func (r *RoleSSOMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state RoleSSOMappingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleSSOMappingIdentityModel{UserRoleID: state.UserRoleID, SSOGroup: state.SSOGroup, TenantID: state.TenantID})...)
+	roleID, group := state.UserRoleID.ValueString(), state.SSOGroup.ValueString()
+
+	apiResp, err := GetRole(ctx, client, roleID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status: 404") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading role %s: %s", roleID, err))
+		return
+	}
+
+	if !slices.Contains(apiResp.SsoGroupMapping, group) {
+		tflog.Debug(ctx, "IdP group no longer mapped to role", map[string]any{"user_role_id": roleID, "sso_group": group})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// UPDATE
+func (r *RoleSSOMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement.
+	var plan RoleSSOMappingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// DELETE
+func (r *RoleSSOMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state RoleSSOMappingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	roleID, group := state.UserRoleID.ValueString(), state.SSOGroup.ValueString()
+
+	err := UpdateSSOGroups(ctx, client, roleID, func(groups []string) []string {
+		return slices.DeleteFunc(groups, func(g string) bool { return g == group })
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "status: 404") {
+			// role already gone
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error unmapping IdP group %q from role %s: %s", group, roleID, err))
+	}
+}
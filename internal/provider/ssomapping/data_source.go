@@ -0,0 +1,147 @@
+package ssomapping
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var (
+	_ datasource.DataSource              = &RoleSSOMappingsDataSource{}
+	_ datasource.DataSourceWithConfigure = &RoleSSOMappingsDataSource{}
+)
+
+func NewRoleSSOMappingsDataSource() datasource.DataSource {
+	return &RoleSSOMappingsDataSource{}
+}
+
+// RoleSSOMappingsDataSource lists the IdP group mappings of all roles of the tenant.
+type RoleSSOMappingsDataSource struct {
+	client *clients.Client
+}
+
+func (d *RoleSSOMappingsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_sso_mappings"
+}
+
+func (d *RoleSSOMappingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the identity provider (IdP) group mappings of the user roles of the tenant and reports IdP groups that map to roles of different scopes. " +
+			"The Ident Service cannot list roles, so roles are discovered through the role assignments of the users of the tenant, which takes one request per user. " +
+			"Roles without members are not discovered and their mappings are neither listed nor checked for conflicts unless they are listed in `user_role_ids`.",
+		Attributes: map[string]schema.Attribute{
+			"user_role_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional roles to include, for example roles that are not assigned to any user.",
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+				},
+			},
+			"mappings": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "IdP group mappings, sorted by sso_group and user_role_id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"sso_group":     schema.StringAttribute{Computed: true, Description: "Name of the IdP group."},
+						"user_role_id":  schema.StringAttribute{Computed: true, Description: "Unique identifier for the user role."},
+						"role_name":     schema.StringAttribute{Computed: true, Description: "Name of the user role."},
+						"role_group_id": schema.StringAttribute{Computed: true, Description: "Group id of the role, null for tenant wide roles."},
+					},
+				},
+			},
+			"conflicts": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "IdP groups that map to roles scoped to different resource groups, sorted by sso_group. A warning is raised for each conflict.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"sso_group": schema.StringAttribute{Computed: true, Description: "Name of the IdP group."},
+						"user_role_ids": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Sorted unique identifiers of the roles the IdP group maps to.",
+						},
+						"role_group_ids": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Sorted group ids of these roles, `*` for tenant wide roles.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RoleSSOMappingsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// ProviderData is nil until the provider has been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *RoleSSOMappingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state RoleSSOMappingsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var extra []string
+	resp.Diagnostics.Append(state.UserRoleIDs.ElementsAs(ctx, &extra, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, err := CollectRoles(ctx, d.client, extra)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error collecting roles: %s", err))
+		return
+	}
+
+	mappings := Mappings(roles)
+	state.Mappings = make([]MappingModel, 0, len(mappings))
+	for _, m := range mappings {
+		state.Mappings = append(state.Mappings, MappingModel{
+			SSOGroup:    types.StringValue(m.SSOGroup),
+			UserRoleID:  types.StringValue(m.UserRoleID),
+			RoleName:    types.StringValue(m.RoleName),
+			RoleGroupID: types.StringPointerValue(m.RoleGroupID),
+		})
+	}
+
+	conflicts := Conflicts(mappings)
+	state.Conflicts = make([]ConflictModel, 0, len(conflicts))
+	for _, c := range conflicts {
+		resp.Diagnostics.AddWarning("Conflicting IdP Group Scopes", c.String())
+		state.Conflicts = append(state.Conflicts, ConflictModel{
+			SSOGroup:     types.StringValue(c.SSOGroup),
+			UserRoleIDs:  c.UserRoleIDs,
+			RoleGroupIDs: c.RoleGroupIDs,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,220 @@
+// Package ssomapping manages the mapping of identity provider (IdP) groups to
+// user roles, stored in the sso_group_mapping of each role.
+package ssomapping
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/role"
+	"github.com/sda/terraform-provider-sda/internal/provider/rolemembership"
+	"github.com/sda/terraform-provider-sda/internal/provider/user"
+)
+
+// tenantWideScope is reported as the scope of roles without a group_id.
+const tenantWideScope = "*"
+
+// maxUpdateAttempts is how often UpdateSSOGroups reads the role again when its
+// object_version changed concurrently.
+const maxUpdateAttempts = 5
+
+// roleLocks serializes the updates of the sso_group_mapping of each role, as
+// Terraform creates and deletes the mappings of a role in parallel.
+var roleLocks sync.Map
+
+// Mapping maps an IdP group to a role.
+type Mapping struct {
+	SSOGroup    string
+	UserRoleID  string
+	RoleName    string
+	RoleGroupID *string
+}
+
+// Conflict is an IdP group that maps to roles with different scopes.
+type Conflict struct {
+	SSOGroup     string
+	UserRoleIDs  []string
+	RoleGroupIDs []string
+}
+
+// GetRole reads the role roleID.
+func GetRole(ctx context.Context, client *clients.Client, roleID string) (*role.RoleAPIResponse, error) {
+	var r role.RoleAPIResponse
+	if err := getJSON(ctx, client, fmt.Sprintf("%s/ident/v1/user_role/%s", client.HostURL, roleID), &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// UpdateSSOGroups replaces the sso_group_mapping of the role roleID with the
+// result of update. Updates of the same role are serialized, and the role is
+// read again when it was changed elsewhere in the meantime, so that concurrent
+// updates do not fail or overwrite each other.
+func UpdateSSOGroups(ctx context.Context, client *clients.Client, roleID string, update func(groups []string) []string) error {
+	mu, _ := roleLocks.LoadOrStore(roleID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	for attempt := 1; ; attempt++ {
+		r, err := GetRole(ctx, client, roleID)
+		if err != nil {
+			return err
+		}
+
+		groups := update(slices.Clone(r.SsoGroupMapping))
+		if slices.Equal(groups, r.SsoGroupMapping) {
+			return nil
+		}
+
+		err = SetSSOGroups(ctx, client, r, groups)
+		if err == nil || !strings.Contains(err.Error(), "status: 409") || attempt == maxUpdateAttempts {
+			return err
+		}
+		tflog.Debug(ctx, "Role changed concurrently, reading it again", map[string]any{"user_role_id": roleID, "attempt": attempt})
+	}
+}
+
+// SetSSOGroups replaces the sso_group_mapping of r, guarded by its object_version.
+func SetSSOGroups(ctx context.Context, client *clients.Client, r *role.RoleAPIResponse, groups []string) error {
+	body, err := json.Marshal(map[string]any{
+		"object_version":    r.ObjectVersion,
+		"sso_group_mapping": groups,
+	})
+	if err != nil {
+		return err
+	}
+
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("%s/ident/v1/user_role/%s", client.HostURL, r.UserRoleID), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	reqHTTP.Header.Set("Content-Type", "application/json")
+
+	_, err = client.DoRequest(reqHTTP, nil)
+	return err
+}
+
+// CollectRoles returns the roles of the tenant. The Ident Service cannot list
+// roles, so they are collected from the role assignments of every user of the
+// tenant, plus the roles in extraRoleIDs. This takes a request per user, and
+// roles without members are only found through extraRoleIDs, so it is used by
+// the data source only and not at plan time.
+func CollectRoles(ctx context.Context, client *clients.Client, extraRoleIDs []string) ([]role.RoleAPIResponse, error) {
+	users, err := user.ListTenantUsers(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]role.RoleAPIResponse{}
+	for _, u := range users {
+		roles, err := rolemembership.ListUserRoles(ctx, client, u.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("listing roles of user %s: %w", u.UserID, err)
+		}
+		for _, r := range roles {
+			byID[r.UserRoleID] = r
+		}
+	}
+
+	for _, id := range extraRoleIDs {
+		if _, ok := byID[id]; ok {
+			continue
+		}
+		r, err := GetRole(ctx, client, id)
+		if err != nil {
+			return nil, fmt.Errorf("reading role %s: %w", id, err)
+		}
+		byID[id] = *r
+	}
+
+	roles := make([]role.RoleAPIResponse, 0, len(byID))
+	for _, r := range byID {
+		roles = append(roles, r)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].UserRoleID < roles[j].UserRoleID })
+	return roles, nil
+}
+
+// Mappings returns the IdP group mappings of roles, sorted by group and role.
+func Mappings(roles []role.RoleAPIResponse) []Mapping {
+	var mappings []Mapping
+	for _, r := range roles {
+		for _, group := range r.SsoGroupMapping {
+			mappings = append(mappings, Mapping{SSOGroup: group, UserRoleID: r.UserRoleID, RoleName: r.Name, RoleGroupID: r.GroupID})
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].SSOGroup != mappings[j].SSOGroup {
+			return mappings[i].SSOGroup < mappings[j].SSOGroup
+		}
+		return mappings[i].UserRoleID < mappings[j].UserRoleID
+	})
+	return mappings
+}
+
+// Conflicts returns the IdP groups that map to roles of more than one scope,
+// where the scope of a role is its group_id.
+func Conflicts(mappings []Mapping) []Conflict {
+	roleIDs := map[string][]string{}
+	scopes := map[string]map[string]bool{}
+	for _, m := range mappings {
+		scope := tenantWideScope
+		if m.RoleGroupID != nil && *m.RoleGroupID != "" {
+			scope = *m.RoleGroupID
+		}
+		if scopes[m.SSOGroup] == nil {
+			scopes[m.SSOGroup] = map[string]bool{}
+		}
+		scopes[m.SSOGroup][scope] = true
+		roleIDs[m.SSOGroup] = append(roleIDs[m.SSOGroup], m.UserRoleID)
+	}
+
+	var conflicts []Conflict
+	for group, s := range scopes {
+		if len(s) < 2 {
+			continue
+		}
+		c := Conflict{SSOGroup: group, UserRoleIDs: roleIDs[group]}
+		for scope := range s {
+			c.RoleGroupIDs = append(c.RoleGroupIDs, scope)
+		}
+		sort.Strings(c.UserRoleIDs)
+		sort.Strings(c.RoleGroupIDs)
+		conflicts = append(conflicts, c)
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].SSOGroup < conflicts[j].SSOGroup })
+	return conflicts
+}
+
+// String describes the conflict for diagnostics.
+func (c Conflict) String() string {
+	return fmt.Sprintf("IdP group %q maps to roles %s, which are scoped to different resource groups (%s). Members of the IdP group receive all of these roles.",
+		c.SSOGroup, strings.Join(c.UserRoleIDs, ", "), strings.Join(c.RoleGroupIDs, ", "))
+}
+
+func getJSON(ctx context.Context, client *clients.Client, url string, v any) error {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resBody, v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package ssomapping
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/role"
+)
+
+func TestConflicts(t *testing.T) {
+	rg1, rg2 := "rg1", "rg2"
+	roles := []role.RoleAPIResponse{
+		{UserRoleID: "r1", Name: "Operators", GroupID: &rg1, SsoGroupMapping: []string{"ops", "admins"}},
+		{UserRoleID: "r2", Name: "Viewers", GroupID: &rg2, SsoGroupMapping: []string{"ops"}},
+		{UserRoleID: "r3", Name: "Admins", SsoGroupMapping: []string{"admins"}},
+		{UserRoleID: "r4", Name: "Auditors", GroupID: &rg1, SsoGroupMapping: []string{"audit"}},
+		{UserRoleID: "r5", Name: "Auditors 2", GroupID: &rg1, SsoGroupMapping: []string{"audit"}},
+	}
+
+	mappings := Mappings(roles)
+	if len(mappings) != 6 || mappings[0].SSOGroup != "admins" || mappings[0].UserRoleID != "r1" {
+		t.Fatalf("mappings = %+v", mappings)
+	}
+
+	want := []Conflict{
+		{SSOGroup: "admins", UserRoleIDs: []string{"r1", "r3"}, RoleGroupIDs: []string{"*", "rg1"}},
+		{SSOGroup: "ops", UserRoleIDs: []string{"r1", "r2"}, RoleGroupIDs: []string{"rg1", "rg2"}},
+	}
+	if got := Conflicts(mappings); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Conflicts = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+
+	groups := []string{"existing"}
+	var patches []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ident/v1/user_role/r1" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var body map[string]any
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &body)
+			patches = append(patches, body)
+			groups = nil
+			for _, g := range body["sso_group_mapping"].([]any) {
+				groups = append(groups, g.(string))
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"user_role_id": "r1", "name": "Operators", "object_version": 3, "sso_group_mapping": groups})
+	}))
+	defer server.Close()
+
+	r := &RoleSSOMappingResource{client: &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identitySchemaResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+	objType := schemaResp.Schema.Type().TerraformType(ctx)
+	value := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"tenant_id":    tftypes.NewValue(tftypes.String, nil),
+		"user_role_id": tftypes.NewValue(tftypes.String, "r1"),
+		"sso_group":    tftypes.NewValue(tftypes.String, "ops"),
+	})
+	identity := &tfsdk.ResourceIdentity{Schema: identitySchemaResp.IdentitySchema, Raw: tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil)}
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}, Identity: identity}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: value}}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if !reflect.DeepEqual(groups, []string{"existing", "ops"}) || patches[0]["object_version"] != float64(3) {
+		t.Fatalf("after create groups = %v, patch = %v", groups, patches[0])
+	}
+
+	deleteResp := resource.DeleteResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Delete(ctx, resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: value}}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if !reflect.DeepEqual(groups, []string{"existing"}) {
+		t.Fatalf("after delete groups = %v", groups)
+	}
+}
+
+func TestUpdateSSOGroupsConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	version, groups := 1, []string{"existing"}
+	// Another configuration changes the role once, between the read and the
+	// update of the first mapping.
+	external := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPatch {
+			var body struct {
+				ObjectVersion   int      `json:"object_version"`
+				SSOGroupMapping []string `json:"sso_group_mapping"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if external {
+				external = false
+				version++
+			}
+			if body.ObjectVersion != version {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			version, groups = version+1, body.SSOGroupMapping
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"user_role_id": "r1", "object_version": version, "sso_group_mapping": groups})
+	}))
+	defer server.Close()
+
+	client := &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}
+	var wg sync.WaitGroup
+	for _, group := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := UpdateSSOGroups(context.Background(), client, "r1", func(groups []string) []string { return append(groups, group) })
+			if err != nil {
+				t.Errorf("mapping %s: %s", group, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	slices.Sort(groups)
+	if !reflect.DeepEqual(groups, []string{"a", "b", "c", "d", "existing"}) {
+		t.Fatalf("groups = %v", groups)
+	}
+}
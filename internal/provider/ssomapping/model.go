@@ -0,0 +1,37 @@
+package ssomapping
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RoleSSOMappingResourceModel struct {
+	TenantID   types.String `tfsdk:"tenant_id"`
+	UserRoleID types.String `tfsdk:"user_role_id"`
+	SSOGroup   types.String `tfsdk:"sso_group"`
+}
+
+// RoleSSOMappingIdentityModel maps the resource identity schema used by import blocks.
+type RoleSSOMappingIdentityModel struct {
+	UserRoleID types.String `tfsdk:"user_role_id"`
+	SSOGroup   types.String `tfsdk:"sso_group"`
+	TenantID   types.String `tfsdk:"tenant_id"`
+}
+
+type RoleSSOMappingsDataSourceModel struct {
+	UserRoleIDs types.List      `tfsdk:"user_role_ids"`
+	Mappings    []MappingModel  `tfsdk:"mappings"`
+	Conflicts   []ConflictModel `tfsdk:"conflicts"`
+}
+
+type MappingModel struct {
+	SSOGroup    types.String `tfsdk:"sso_group"`
+	UserRoleID  types.String `tfsdk:"user_role_id"`
+	RoleName    types.String `tfsdk:"role_name"`
+	RoleGroupID types.String `tfsdk:"role_group_id"`
+}
+
+type ConflictModel struct {
+	SSOGroup     types.String `tfsdk:"sso_group"`
+	UserRoleIDs  []string     `tfsdk:"user_role_ids"`
+	RoleGroupIDs []string     `tfsdk:"role_group_ids"`
+}
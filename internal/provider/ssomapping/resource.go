@@ -0,0 +1,119 @@
+package ssomapping
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+	"github.com/sda/terraform-provider-sda/internal/provider/validators"
+)
+
+var _ resource.Resource = &RoleSSOMappingResource{}
+var _ resource.ResourceWithImportState = &RoleSSOMappingResource{}
+var _ resource.ResourceWithIdentity = &RoleSSOMappingResource{}
+
+func NewRoleSSOMappingResource() resource.Resource {
+	return &RoleSSOMappingResource{}
+}
+
+type RoleSSOMappingResource struct {
+	client *clients.Client
+}
+
+func (r *RoleSSOMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_sso_mapping"
+}
+
+func (r *RoleSSOMappingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Maps an identity provider (IdP) group to a user role in the SDA Ident Service. The mapping is managed non-authoritatively: other IdP groups mapped to the same role, by other configurations or in the UI, are left untouched. Do not combine it with the `sso_group_mapping` attribute of `sda_role` for the same role. Use the `sda_role_sso_mappings` data source to detect IdP groups mapped to roles of different scopes.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": tenancy.Attribute(),
+			"user_role_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Unique identifier for the user role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sso_group": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the IdP group whose members receive the role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.SSOGroupName(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoleSSOMappingResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *RoleSSOMappingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"tenant_id": tenancy.IdentityAttribute(),
+			"user_role_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the user role.",
+			},
+			"sso_group": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Name of the IdP group.",
+			},
+		},
+	}
+}
+
+func (r *RoleSSOMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity RoleSSOMappingIdentityModel
+
+	if req.ID != "" {
+		// Import format: [tenant:<tenant_id>/]user_role_id/sso_group
+		tenantID, id, err := tenancy.SplitImportID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		idParts, err := importid.Split(id, 2)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected import ID format: user_role_id/sso_group, got: %s (%s)", req.ID, err),
+			)
+			return
+		}
+		identity.TenantID = tenantID
+		identity.UserRoleID = types.StringValue(idParts[0])
+		identity.SSOGroup = types.StringValue(idParts[1])
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), identity.TenantID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_role_id"), identity.UserRoleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sso_group"), identity.SSOGroup)...)
+}
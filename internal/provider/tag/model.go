@@ -25,3 +25,8 @@ type TagAPIResponse struct {
 	Color             *string `json:"color"`
 	Icon              *string `json:"icon"`
 }
+
+// TagIdentityModel maps the resource identity schema used by import blocks.
+type TagIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
@@ -0,0 +1,108 @@
+// Package tenancy implements the optional tenant_id attribute of Ident Service
+// resources, which manages a resource in another tenant than the one selected
+// by the provider configuration.
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+// importPrefix marks the tenant of an import ID, as in tenant:<tenant_id>/<id>.
+const importPrefix = "tenant:"
+
+// Attribute returns the schema of the tenant_id attribute.
+func Attribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Description: "Tenant the resource is managed in. Defaults to the tenant of the provider configuration. " +
+			"The provider user must belong to the tenant; its token is obtained once per tenant and reused.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// IdentityAttribute returns the identity schema of the tenant_id attribute.
+func IdentityAttribute() identityschema.StringAttribute {
+	return identityschema.StringAttribute{
+		OptionalForImport: true,
+		Description:       "Tenant the resource is managed in, defaults to the tenant of the provider configuration.",
+	}
+}
+
+// RequireClient reports whether the provider configured client, and adds an
+// error to diags when it did not because its configuration is not known yet.
+func RequireClient(client *clients.Client, diags *diag.Diagnostics) bool {
+	if client == nil {
+		diags.AddError("Provider Not Configured", clients.ErrNotConfigured.Error())
+		return false
+	}
+	return true
+}
+
+// Client returns client scoped to tenantID, or client itself when tenantID is
+// null. A nil client is reported like RequireClient does.
+func Client(client *clients.Client, tenantID types.String) (*clients.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !RequireClient(client, &diags) {
+		return nil, diags
+	}
+	if tenantID.IsNull() || tenantID.IsUnknown() {
+		return client, diags
+	}
+
+	scoped, err := client.ForTenant(tenantID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("tenant_id"), "Tenant Selection Error",
+			fmt.Sprintf("Unable to obtain a token for tenant %s: %s", tenantID.ValueString(), err))
+		return nil, diags
+	}
+	return scoped, diags
+}
+
+// SplitImportID splits an optional tenant:<tenant_id>/ prefix off an import ID.
+func SplitImportID(id string) (types.String, string, error) {
+	if !strings.HasPrefix(id, importPrefix) {
+		return types.StringNull(), id, nil
+	}
+
+	tenantID, rest, ok := strings.Cut(strings.TrimPrefix(id, importPrefix), "/")
+	if !ok || tenantID == "" || rest == "" {
+		return types.StringNull(), "", fmt.Errorf("expected tenant:<tenant_id>/<id>, got %q", id)
+	}
+	return types.StringValue(tenantID), rest, nil
+}
+
+// ImportStatePassthrough imports a resource identified by the attribute at
+// idPath, by import ID with an optional tenant prefix or by identity.
+func ImportStatePassthrough(ctx context.Context, idPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tenantID := types.StringNull()
+	if req.ID != "" {
+		var err error
+		if tenantID, req.ID, err = SplitImportID(req.ID); err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+	} else {
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("tenant_id"), &tenantID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), tenantID)...)
+	resource.ImportStatePassthroughWithIdentity(ctx, idPath, idPath, req, resp)
+}
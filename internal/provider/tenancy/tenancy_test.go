@@ -0,0 +1,44 @@
+package tenancy
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitImportID(t *testing.T) {
+	tests := []struct {
+		id, tenantID, rest string
+		wantErr            bool
+	}{
+		{id: "r1", rest: "r1"},
+		{id: "u1/r1", rest: "u1/r1"},
+		{id: "tenant:t2/r1", tenantID: "t2", rest: "r1"},
+		{id: "tenant:t2/u1/r1", tenantID: "t2", rest: "u1/r1"},
+		{id: "tenant:t2", wantErr: true},
+		{id: "tenant:/r1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tenantID, rest, err := SplitImportID(tt.id)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SplitImportID(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if tenantID.ValueString() != tt.tenantID || tenantID.IsNull() != (tt.tenantID == "") || rest != tt.rest {
+			t.Errorf("SplitImportID(%q) = %s, %q", tt.id, tenantID, rest)
+		}
+	}
+}
+
+func TestClientNotConfigured(t *testing.T) {
+	for _, tenantID := range []types.String{types.StringNull(), types.StringValue("t1")} {
+		client, diags := Client(nil, tenantID)
+		if client != nil || !diags.HasError() || diags[0].Summary() != "Provider Not Configured" {
+			t.Errorf("Client(nil, %s) = %v, %v, want the provider not to be configured", tenantID, client, diags)
+		}
+	}
+}
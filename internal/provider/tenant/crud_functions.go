@@ -0,0 +1,122 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+// CREATE
+func (r *TenantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan TenantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Signing up tenant", map[string]any{"company_name": plan.CompanyName.ValueString(), "owner_email": plan.OwnerEmail.ValueString()})
+
+	apiResp, err := r.client.SignUp(signUpRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error creating tenant: %s", err))
+		return
+	}
+
+	plan.TenantID = types.StringValue(apiResp.TenantID)
+	plan.OwnerID = types.StringValue(apiResp.OwnerID)
+	plan.ExistingOwner = types.BoolValue(apiResp.ExistingUser != nil && *apiResp.ExistingUser)
+	plan.CreationTimestamp = types.StringPointerValue(apiResp.CreationTimestamp)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// READ
+func (r *TenantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The Ident Service only reads the selected tenant, and selecting another
+	// tenant would change the session of the provider. The state is kept as is.
+	var state TenantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// UPDATE
+func (r *TenantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require replacement.
+	var plan TenantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// DELETE
+func (r *TenantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TenantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Ident Service cannot delete tenants.
+	tflog.Info(ctx, "Removing tenant from state, the tenant itself is left in place", map[string]any{"tenant_id": state.TenantID.ValueString()})
+}
+
+// PLAN MODIFICATION
+
+// ModifyPlan warns that tenants are never deleted, neither on destroy nor on
+// replacement.
+func (r *TenantResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state TenantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case req.Plan.Raw.IsNull():
+		resp.Diagnostics.AddWarning("Tenant Not Deleted",
+			fmt.Sprintf("The Ident Service cannot delete tenants. Tenant %s is only removed from the Terraform state and remains in place.", state.TenantID.ValueString()))
+	case len(resp.RequiresReplace) > 0:
+		resp.Diagnostics.AddWarning("Tenant Replacement Creates A New Tenant",
+			fmt.Sprintf("Changing %s creates a new tenant. The Ident Service cannot delete tenants, so tenant %s remains in place. "+
+				"Use sda_tenant_settings to change the settings of an existing tenant.", resp.RequiresReplace, state.TenantID.ValueString()))
+	}
+}
+
+// HELPER FUNCTIONS
+
+func signUpRequest(plan TenantResourceModel) clients.CreateTenantRequest {
+	return clients.CreateTenantRequest{
+		FirstName:                 plan.OwnerFirstName.ValueString(),
+		LastName:                  plan.OwnerLastName.ValueString(),
+		Email:                     plan.OwnerEmail.ValueString(),
+		CompanyName:               plan.CompanyName.ValueString(),
+		Password:                  plan.OwnerPassword.ValueString(),
+		PasswordConfirmation:      plan.OwnerPassword.ValueString(),
+		PhoneNumber:               plan.OwnerPhoneNumber.ValueStringPointer(),
+		Locale:                    plan.Locale.ValueStringPointer(),
+		Country:                   plan.Country.ValueStringPointer(),
+		Industry:                  plan.Industry.ValueStringPointer(),
+		EnforceMFA:                plan.EnforceMFA.ValueBoolPointer(),
+		ImportDemoData:            plan.ImportDemoData.ValueBoolPointer(),
+		MaxUserRoleExpirationDays: plan.MaxUserRoleExpirationDays.ValueInt64Pointer(),
+	}
+}
@@ -0,0 +1,61 @@
+package tenant
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestSignUp(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/ident/v1/tenant" {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"tenant_id": "t2", "owner_id": "u1", "company_name": "Site A", "existing_user": true, "creation_timestamp": "2026-10-19T08:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}
+	plan := TenantResourceModel{
+		CompanyName:               types.StringValue("Site A"),
+		OwnerEmail:                types.StringValue("ops@example.com"),
+		OwnerFirstName:            types.StringValue("Ops"),
+		OwnerLastName:             types.StringValue("Team"),
+		OwnerPassword:             types.StringValue("s3cret!"),
+		OwnerPhoneNumber:          types.StringNull(),
+		Locale:                    types.StringNull(),
+		Country:                   types.StringValue("DE"),
+		Industry:                  types.StringNull(),
+		EnforceMFA:                types.BoolValue(true),
+		ImportDemoData:            types.BoolValue(false),
+		MaxUserRoleExpirationDays: types.Int64Null(),
+	}
+
+	resp, err := client.SignUp(signUpRequest(plan))
+	if err != nil {
+		t.Fatalf("SignUp: %s", err)
+	}
+	if resp.TenantID != "t2" || resp.ExistingUser == nil || !*resp.ExistingUser {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	if body["password_confirmation"] != "s3cret!" || body["import_demo_data"] != false || body["country"] != "DE" {
+		t.Fatalf("request body = %v", body)
+	}
+	for _, unset := range []string{"phone_number", "locale", "industry", "max_user_role_expiration_days"} {
+		if _, ok := body[unset]; ok {
+			t.Errorf("request body contains unset %s", unset)
+		}
+	}
+}
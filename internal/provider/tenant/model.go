@@ -0,0 +1,24 @@
+package tenant
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type TenantResourceModel struct {
+	TenantID                  types.String `tfsdk:"tenant_id"`
+	OwnerID                   types.String `tfsdk:"owner_id"`
+	ExistingOwner             types.Bool   `tfsdk:"existing_owner"`
+	CreationTimestamp         types.String `tfsdk:"creation_timestamp"`
+	CompanyName               types.String `tfsdk:"company_name"`
+	OwnerEmail                types.String `tfsdk:"owner_email"`
+	OwnerFirstName            types.String `tfsdk:"owner_first_name"`
+	OwnerLastName             types.String `tfsdk:"owner_last_name"`
+	OwnerPassword             types.String `tfsdk:"owner_password"`
+	OwnerPhoneNumber          types.String `tfsdk:"owner_phone_number"`
+	Locale                    types.String `tfsdk:"locale"`
+	Country                   types.String `tfsdk:"country"`
+	Industry                  types.String `tfsdk:"industry"`
+	EnforceMFA                types.Bool   `tfsdk:"enforce_mfa"`
+	ImportDemoData            types.Bool   `tfsdk:"import_demo_data"`
+	MaxUserRoleExpirationDays types.Int64  `tfsdk:"max_user_role_expiration_days"`
+}
@@ -0,0 +1,162 @@
+// Package tenant provisions new SDA tenants. Creating a tenant does not select
+// it: resources are managed in a tenant through a provider configuration
+// whose tenant_id selects it.
+package tenant
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+var _ resource.Resource = &TenantResource{}
+var _ resource.ResourceWithModifyPlan = &TenantResource{}
+
+func NewTenantResource() resource.Resource {
+	return &TenantResource{}
+}
+
+type TenantResource struct {
+	client *clients.Client
+}
+
+func (r *TenantResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant"
+}
+
+func (r *TenantResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Creates (signs up) a new tenant in the SDA Ident Service, for example one tenant per customer site. " +
+			"Creating a tenant does not select it: to manage resources in the new tenant, configure a provider alias with `tenant_id = sda_tenant.<name>.tenant_id` and credentials of the tenant owner. " +
+			"The Ident Service can neither read tenants other than the selected one nor delete tenants, so the attributes are not refreshed, every change creates a new tenant and destroying the resource only removes it from the Terraform state. " +
+			"Manage the settings of an existing tenant with `sda_tenant_settings` through a provider configured for that tenant.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for the tenant. Use it as the `tenant_id` of a provider configuration to select the tenant.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier of the user owning the tenant.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"existing_owner": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether `owner_email` belonged to an existing user, who keeps their password, instead of a user created with the tenant.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_timestamp": schema.StringAttribute{
+				Computed:    true,
+				Description: "Date and time when the tenant was created (ISO 8601 format).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"company_name": schema.StringAttribute{
+				Required:      true,
+				Description:   "Company name of the tenant.",
+				PlanModifiers: requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"owner_email": schema.StringAttribute{
+				Required:      true,
+				Description:   "Email address of the tenant owner. Use the provider user to be able to select the tenant with the same credentials.",
+				PlanModifiers: requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"owner_first_name": schema.StringAttribute{
+				Required:      true,
+				Description:   "First name of the tenant owner.",
+				PlanModifiers: requiresReplace,
+			},
+			"owner_last_name": schema.StringAttribute{
+				Required:      true,
+				Description:   "Last name of the tenant owner.",
+				PlanModifiers: requiresReplace,
+			},
+			"owner_password": schema.StringAttribute{
+				Required:      true,
+				Sensitive:     true,
+				Description:   "Password of the tenant owner, used when the owner is created with the tenant.",
+				PlanModifiers: requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"owner_phone_number": schema.StringAttribute{
+				Optional:      true,
+				Description:   "Phone number of the tenant owner.",
+				PlanModifiers: requiresReplace,
+			},
+			"locale": schema.StringAttribute{
+				Optional:      true,
+				Description:   "Locale of the tenant owner.",
+				PlanModifiers: requiresReplace,
+			},
+			"country": schema.StringAttribute{
+				Optional:      true,
+				Description:   "Initial country of the tenant.",
+				PlanModifiers: requiresReplace,
+			},
+			"industry": schema.StringAttribute{
+				Optional:      true,
+				Description:   "Initial industry of the tenant.",
+				PlanModifiers: requiresReplace,
+			},
+			"enforce_mfa": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Initial multi-factor authentication enforcement of the tenant.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"import_demo_data": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether demo data is imported into the tenant. Defaults to `true` in the Ident Service.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_user_role_expiration_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Initial maximum number of days a user role assignment may last.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *TenantResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
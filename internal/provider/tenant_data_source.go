@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -97,9 +98,13 @@ func (d *tenantDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 
 // Read refreshes the Terraform state with the latest data.
 func (d *tenantDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state tenantDataSourceModel
 
-	tenant, err := d.GetTenant()
+	tenant, err := d.GetTenant(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read SDA Tenant",
@@ -127,9 +132,9 @@ func (d *tenantDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 }
 
-func (d *tenantDataSource) GetTenant() (apiTenantModel, error) { // Note the return type: apiTenantModel
+func (d *tenantDataSource) GetTenant(ctx context.Context) (apiTenantModel, error) { // Note the return type: apiTenantModel
 	// Use standard http request construction
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/ident/v1/tenant", d.client.HostURL), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/ident/v1/tenant", d.client.HostURL), nil)
 	if err != nil {
 		return apiTenantModel{}, err
 	}
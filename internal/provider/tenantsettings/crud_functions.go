@@ -0,0 +1,227 @@
+package tenantsettings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+// CREATE
+func (r *TenantSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan TenantSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(r.client, plan.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The tenant always exists: adopt it and apply the configured settings.
+	current, err := getTenant(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading tenant: %s", err))
+		return
+	}
+
+	apiResp, err := patchTenant(ctx, client, current.ObjectVersion, settingsPayload(plan, *current))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error updating tenant settings: %s", err))
+		return
+	}
+
+	state := stateFromAPI(*apiResp)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, TenantSettingsIdentityModel{TenantID: state.TenantID})...)
+}
+
+// READ
+func (r *TenantSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state TenantSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, TenantSettingsIdentityModel{TenantID: state.TenantID})...)
+
+	apiResp, err := getTenant(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading tenant: %s", err))
+		return
+	}
+
+	if !state.TenantID.IsNull() && state.TenantID.ValueString() != apiResp.TenantID {
+		resp.Diagnostics.AddError("Tenant Mismatch",
+			fmt.Sprintf("The settings in state belong to tenant %s, but the provider is authenticated against tenant %s.", state.TenantID.ValueString(), apiResp.TenantID))
+		return
+	}
+
+	state = stateFromAPI(*apiResp)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// UPDATE
+func (r *TenantSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state TenantSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(r.client, plan.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The object_version from state makes the API reject the update when the
+	// settings were changed since the last refresh.
+	current := TenantAPIResponse{
+		CompanyName:               state.CompanyName.ValueString(),
+		Country:                   state.Country.ValueStringPointer(),
+		Industry:                  state.Industry.ValueStringPointer(),
+		EnforceMFA:                state.EnforceMFA.ValueBoolPointer(),
+		ImportDemoData:            state.ImportDemoData.ValueBoolPointer(),
+		MaxUserRoleExpirationDays: state.MaxUserRoleExpirationDays.ValueInt64Pointer(),
+	}
+	apiResp, err := patchTenant(ctx, client, state.ObjectVersion.ValueInt64(), settingsPayload(plan, current))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error updating tenant settings: %s", err))
+		return
+	}
+
+	state = stateFromAPI(*apiResp)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, TenantSettingsIdentityModel{TenantID: state.TenantID})...)
+}
+
+// DELETE
+func (r *TenantSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The tenant cannot be deleted through its settings, so the settings are
+	// left as they are and the resource is only removed from state.
+	tflog.Info(ctx, "Removing tenant settings from state, the tenant is left unchanged")
+}
+
+// HELPER FUNCTIONS
+
+// settingsPayload returns the configured settings of plan that differ from current.
+func settingsPayload(plan TenantSettingsResourceModel, current TenantAPIResponse) map[string]any {
+	payload := map[string]any{}
+	if known(plan.CompanyName) && plan.CompanyName.ValueString() != current.CompanyName {
+		payload["company_name"] = plan.CompanyName.ValueString()
+	}
+	if known(plan.Country) && !plan.Country.Equal(types.StringPointerValue(current.Country)) {
+		payload["country"] = plan.Country.ValueString()
+	}
+	if known(plan.Industry) && !plan.Industry.Equal(types.StringPointerValue(current.Industry)) {
+		payload["industry"] = plan.Industry.ValueString()
+	}
+	if known(plan.EnforceMFA) && !plan.EnforceMFA.Equal(types.BoolPointerValue(current.EnforceMFA)) {
+		payload["enforce_mfa"] = plan.EnforceMFA.ValueBool()
+	}
+	if known(plan.ImportDemoData) && !plan.ImportDemoData.Equal(types.BoolPointerValue(current.ImportDemoData)) {
+		payload["import_demo_data"] = plan.ImportDemoData.ValueBool()
+	}
+	if known(plan.MaxUserRoleExpirationDays) && !plan.MaxUserRoleExpirationDays.Equal(types.Int64PointerValue(current.MaxUserRoleExpirationDays)) {
+		payload["max_user_role_expiration_days"] = plan.MaxUserRoleExpirationDays.ValueInt64()
+	}
+	return payload
+}
+
+func known(v interface {
+	IsNull() bool
+	IsUnknown() bool
+}) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
+
+func stateFromAPI(apiResp TenantAPIResponse) TenantSettingsResourceModel {
+	return TenantSettingsResourceModel{
+		TenantID:                  types.StringValue(apiResp.TenantID),
+		CompanyName:               types.StringValue(apiResp.CompanyName),
+		Country:                   types.StringPointerValue(apiResp.Country),
+		Industry:                  types.StringPointerValue(apiResp.Industry),
+		EnforceMFA:                types.BoolPointerValue(apiResp.EnforceMFA),
+		ImportDemoData:            types.BoolPointerValue(apiResp.ImportDemoData),
+		MaxUserRoleExpirationDays: types.Int64PointerValue(apiResp.MaxUserRoleExpirationDays),
+		ObjectVersion:             types.Int64Value(apiResp.ObjectVersion),
+	}
+}
+
+func getTenant(ctx context.Context, client *clients.Client) (*TenantAPIResponse, error) {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/ident/v1/tenant", client.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp TenantAPIResponse
+	if err := json.Unmarshal(resBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &apiResp, nil
+}
+
+// patchTenant sends the settings in payload, guarded by objectVersion. When
+// nothing changed the tenant is only read.
+func patchTenant(ctx context.Context, client *clients.Client, objectVersion int64, payload map[string]any) (*TenantAPIResponse, error) {
+	if len(payload) == 0 {
+		return getTenant(ctx, client)
+	}
+	payload["object_version"] = objectVersion
+	tflog.Debug(ctx, "Updating tenant settings", map[string]any{"payload": payload})
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("%s/ident/v1/tenant", client.HostURL), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	reqHTTP.Header.Set("Content-Type", "application/json")
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp TenantAPIResponse
+	if err := json.Unmarshal(resBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &apiResp, nil
+}
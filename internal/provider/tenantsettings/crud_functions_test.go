@@ -0,0 +1,61 @@
+package tenantsettings
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestSettingsPayload(t *testing.T) {
+	country, mfa := "DE", false
+	current := TenantAPIResponse{CompanyName: "ACME", Country: &country, EnforceMFA: &mfa}
+
+	plan := TenantSettingsResourceModel{
+		CompanyName:               types.StringValue("ACME"),
+		Country:                   types.StringValue("AT"),
+		Industry:                  types.StringUnknown(),
+		EnforceMFA:                types.BoolValue(true),
+		ImportDemoData:            types.BoolNull(),
+		MaxUserRoleExpirationDays: types.Int64Value(90),
+	}
+
+	want := map[string]any{"country": "AT", "enforce_mfa": true, "max_user_role_expiration_days": int64(90)}
+	if got := settingsPayload(plan, current); !reflect.DeepEqual(got, want) {
+		t.Fatalf("settingsPayload = %v, want %v", got, want)
+	}
+}
+
+func TestPatchTenant(t *testing.T) {
+	var patched map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &patched)
+		}
+		w.Write([]byte(`{"tenant_id": "t1", "owner_id": "u1", "company_name": "ACME", "enforce_mfa": true, "object_version": 8}`))
+	}))
+	defer server.Close()
+
+	client := &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	apiResp, err := patchTenant(context.Background(), client, 7, map[string]any{"enforce_mfa": true})
+	if err != nil {
+		t.Fatalf("patchTenant: %s", err)
+	}
+	if patched["object_version"] != float64(7) || patched["enforce_mfa"] != true {
+		t.Fatalf("PATCH body = %v", patched)
+	}
+
+	state := stateFromAPI(*apiResp)
+	if state.TenantID.ValueString() != "t1" || !state.EnforceMFA.ValueBool() || !state.Country.IsNull() || state.ObjectVersion.ValueInt64() != 8 {
+		t.Fatalf("state = %+v", state)
+	}
+}
@@ -0,0 +1,33 @@
+package tenantsettings
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type TenantSettingsResourceModel struct {
+	TenantID                  types.String `tfsdk:"tenant_id"`
+	CompanyName               types.String `tfsdk:"company_name"`
+	Country                   types.String `tfsdk:"country"`
+	Industry                  types.String `tfsdk:"industry"`
+	EnforceMFA                types.Bool   `tfsdk:"enforce_mfa"`
+	ImportDemoData            types.Bool   `tfsdk:"import_demo_data"`
+	MaxUserRoleExpirationDays types.Int64  `tfsdk:"max_user_role_expiration_days"`
+	ObjectVersion             types.Int64  `tfsdk:"object_version"`
+}
+
+// TenantAPIResponse holds the fields of TenantResponse managed by this resource.
+type TenantAPIResponse struct {
+	ObjectVersion             int64   `json:"object_version"`
+	TenantID                  string  `json:"tenant_id"`
+	CompanyName               string  `json:"company_name"`
+	Country                   *string `json:"country"`
+	Industry                  *string `json:"industry"`
+	EnforceMFA                *bool   `json:"enforce_mfa"`
+	ImportDemoData            *bool   `json:"import_demo_data"`
+	MaxUserRoleExpirationDays *int64  `json:"max_user_role_expiration_days"`
+}
+
+// TenantSettingsIdentityModel maps the resource identity schema used by import blocks.
+type TenantSettingsIdentityModel struct {
+	TenantID types.String `tfsdk:"tenant_id"`
+}
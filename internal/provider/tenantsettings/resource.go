@@ -0,0 +1,133 @@
+package tenantsettings
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+var _ resource.Resource = &TenantSettingsResource{}
+var _ resource.ResourceWithImportState = &TenantSettingsResource{}
+var _ resource.ResourceWithIdentity = &TenantSettingsResource{}
+
+func NewTenantSettingsResource() resource.Resource {
+	return &TenantSettingsResource{}
+}
+
+type TenantSettingsResource struct {
+	client *clients.Client
+}
+
+func (r *TenantSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_settings"
+}
+
+func (r *TenantSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the settings of a tenant, by default the tenant the provider is authenticated against. The tenant always exists, so declare at most one `sda_tenant_settings` per tenant. " +
+			"Only configured settings are managed; settings that are not configured, or removed from the configuration, keep their current value. Destroying the resource only removes it from the Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Tenant whose settings are managed. Defaults to the tenant of the provider configuration.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"company_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Company name of the tenant.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"country": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Country of the tenant.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"industry": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Industry of the tenant.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enforce_mfa": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether users of the tenant must sign in with multi-factor authentication.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"import_demo_data": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether demo data is imported into the tenant.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_user_role_expiration_days": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum number of days a user role assignment may last. Assignments of `sda_user_role_association` are checked against it at plan time.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"object_version": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Version number of the object, used for optimistic locking and change tracking.",
+			},
+		},
+	}
+}
+
+func (r *TenantSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*clients.Client)
+}
+
+func (r *TenantSettingsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"tenant_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the tenant.",
+			},
+		},
+	}
+}
+
+func (r *TenantSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("tenant_id"), path.Root("tenant_id"), req, resp)
+}
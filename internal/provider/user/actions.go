@@ -0,0 +1,170 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+)
+
+var (
+	_ action.Action              = &UserConfirmAction{}
+	_ action.ActionWithConfigure = &UserConfirmAction{}
+	_ action.Action              = &UserPasswordResetAction{}
+	_ action.ActionWithConfigure = &UserPasswordResetAction{}
+)
+
+func NewUserConfirmAction() action.Action {
+	return &UserConfirmAction{}
+}
+
+func NewUserPasswordResetAction() action.Action {
+	return &UserPasswordResetAction{}
+}
+
+// UserConfirmAction confirms the sign up of a user.
+type UserConfirmAction struct {
+	client *clients.Client
+}
+
+// UserPasswordResetAction sends a password reset code to a user.
+type UserPasswordResetAction struct {
+	client *clients.Client
+}
+
+func (a *UserConfirmAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_confirm"
+}
+
+func (a *UserConfirmAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Confirms the sign up of a user of the SDA Ident Service with the confirmation code sent to the user.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "Username of the user, their email address.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"confirmation_code": schema.StringAttribute{
+				Required:    true,
+				Description: "Confirmation code sent to the user. Action configuration is not stored in the state; pass the code through a sensitive variable to keep it out of the plan output.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *UserConfirmAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = configureActionClient(req, resp)
+}
+
+func (a *UserConfirmAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if !tenancy.RequireClient(a.client, &resp.Diagnostics) {
+		return
+	}
+
+	var config UserConfirmActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := a.client.ConfirmUser(clients.ConfirmUserRequest{
+		Username:         config.Username.ValueString(),
+		ConfirmationCode: config.ConfirmationCode.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error confirming user %s: %s", config.Username.ValueString(), err))
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Confirmed user %s", config.Username.ValueString())})
+}
+
+func (a *UserPasswordResetAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_password_reset"
+}
+
+func (a *UserPasswordResetAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Starts the password reset of a user of the SDA Ident Service, which sends the user a code to choose a new password. " +
+			"Trigger it after creating a `sda_user` to onboard the user in a single apply. The delivery medium and destination of the code are reported as progress of the action.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "Username of the user, their email address.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *UserPasswordResetAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = configureActionClient(req, resp)
+}
+
+func (a *UserPasswordResetAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if !tenancy.RequireClient(a.client, &resp.Diagnostics) {
+		return
+	}
+
+	var config UserPasswordResetActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := a.client.ForgotPassword(clients.ForgotPasswordRequest{Username: config.Username.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error resetting the password of user %s: %s", config.Username.ValueString(), err))
+		return
+	}
+
+	details := apiResp.NextStep.CodeDeliveryDetails
+	tflog.Info(ctx, "Sent password reset code", map[string]any{
+		"username":        config.Username.ValueString(),
+		"delivery_medium": details.DeliveryMedium,
+		"destination":     details.Destination,
+		"next_step":       apiResp.NextStep.ResetPasswordStep,
+	})
+	resp.SendProgress(action.InvokeProgressEvent{Message: deliveryMessage(config.Username.ValueString(), details)})
+}
+
+// deliveryMessage describes where the password reset code of username was sent.
+func deliveryMessage(username string, details clients.CodeDeliveryDetails) string {
+	if details.DeliveryMedium == "" {
+		return fmt.Sprintf("Started the password reset of user %s", username)
+	}
+	return fmt.Sprintf("Sent the password reset code of user %s by %s to %s", username, details.DeliveryMedium, details.Destination)
+}
+
+// configureActionClient returns the provider configured client of an action.
+func configureActionClient(req action.ConfigureRequest, resp *action.ConfigureResponse) *clients.Client {
+	// ProviderData is nil until the provider has been configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+	return client
+}
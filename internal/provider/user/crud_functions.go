@@ -8,9 +8,12 @@ import (
     "net/http"
     "strings"
 
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/resource"
     "github.com/hashicorp/terraform-plugin-framework/types"
 
+    "github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 // Ensure UserResource implements CRUD interfaces
@@ -18,11 +21,20 @@ var _ resource.Resource = &UserResource{}
 
 // CREATE
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+        return
+    }
+
     var plan UserResourceModel
     resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
     if resp.Diagnostics.HasError() {
         return
     }
+    client, diags := tenancy.Client(r.client, plan.TenantID)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
 
     payload := map[string]interface{}{
         "first_name": plan.FirstName.ValueString(),
@@ -58,15 +70,15 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
         return
     }
 
-    url := fmt.Sprintf("%s/ident/v1/user", r.client.HostURL)
-    reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+    url := fmt.Sprintf("%s/ident/v1/user", client.HostURL)
+    reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
     if err != nil {
         resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
         return
     }
     reqHTTP.Header.Set("Content-Type", "application/json")
 
-    resBody, err := r.client.DoRequest(reqHTTP, nil)
+    resBody, err := client.DoRequest(reqHTTP, nil)
     if err != nil {
         errSummary := "API Error"
         if strings.Contains(err.Error(), "status: 403") {
@@ -85,6 +97,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 
     // Build state
     state := UserResourceModel{
+        TenantID:          plan.TenantID,
         ObjectVersion:     types.Int64Value(apiResp.ObjectVersion),
         CreationUserID:    types.StringValue(apiResp.CreationUserID),
         UpdateUserID:      types.StringPointerValue(apiResp.UpdateUserID),
@@ -112,24 +125,35 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
     }
 
     resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+    resp.Diagnostics.Append(resp.Identity.Set(ctx, UserIdentityModel{UserID: state.UserID, TenantID: state.TenantID})...)
 }
 
 // READ
 func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+        return
+    }
+
     var state UserResourceModel
     resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
     if resp.Diagnostics.HasError() {
         return
     }
+    client, diags := tenancy.Client(r.client, state.TenantID)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+    resp.Diagnostics.Append(resp.Identity.Set(ctx, UserIdentityModel{UserID: state.UserID, TenantID: state.TenantID})...)
 
-    url := fmt.Sprintf("%s/ident/v1/user/%s", r.client.HostURL, state.UserID.ValueString())
-    reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+    url := fmt.Sprintf("%s/ident/v1/user/%s", client.HostURL, state.UserID.ValueString())
+    reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
     if err != nil {
         resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
         return
     }
 
-    resBody, err := r.client.DoRequest(reqHTTP, nil)
+    resBody, err := client.DoRequest(reqHTTP, nil)
     if err != nil {
         if strings.Contains(err.Error(), "status: 404") {
             resp.State.RemoveResource(ctx)
@@ -169,12 +193,21 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // UPDATE
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+        return
+    }
+
     var plan, state UserResourceModel
     resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
     resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
     if resp.Diagnostics.HasError() {
         return
     }
+    client, diags := tenancy.Client(r.client, plan.TenantID)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
 
     payload := map[string]interface{}{
         "object_version": state.ObjectVersion.ValueInt64(),
@@ -237,15 +270,15 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
         return
     }
 
-    url := fmt.Sprintf("%s/ident/v1/user/%s", r.client.HostURL, state.UserID.ValueString())
-    reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+    url := fmt.Sprintf("%s/ident/v1/user/%s", client.HostURL, state.UserID.ValueString())
+    reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
     if err != nil {
         resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
         return
     }
     reqHTTP.Header.Set("Content-Type", "application/json")
 
-    resBody, err := r.client.DoRequest(reqHTTP, nil)
+    resBody, err := client.DoRequest(reqHTTP, nil)
     if err != nil {
         resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error updating user: %s\nResponse body:\n%s", err, string(resBody)))
         return
@@ -283,24 +316,34 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
     state.Source = types.StringValue(apiResp.Source)
 
     resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+    resp.Diagnostics.Append(resp.Identity.Set(ctx, UserIdentityModel{UserID: state.UserID, TenantID: state.TenantID})...)
 }
 
 // DELETE
 func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+        return
+    }
+
     var state UserResourceModel
     resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
     if resp.Diagnostics.HasError() {
         return
     }
+    client, diags := tenancy.Client(r.client, state.TenantID)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
 
-    url := fmt.Sprintf("%s/ident/v1/user/%s", r.client.HostURL, state.UserID.ValueString())
-    reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+    url := fmt.Sprintf("%s/ident/v1/user/%s", client.HostURL, state.UserID.ValueString())
+    reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
     if err != nil {
         resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
         return
     }
 
-    _, err = r.client.DoRequest(reqHTTP, nil)
+    _, err = client.DoRequest(reqHTTP, nil)
     if err != nil {
         if strings.Contains(err.Error(), "status: 404") {
             // already gone
@@ -309,3 +352,110 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
         resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error deleting user: %s", err))
     }
 }
+
+// PLAN MODIFICATION
+
+// ModifyPlan enforces that users federated through SAML are owned by the
+// identity provider: they cannot be created or deleted, and the identity
+// provider owned attributes follow the identity provider. Users managed by the
+// Ident Service require these attributes instead.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    if req.Plan.Raw.IsNull() {
+        if req.State.Raw.IsNull() {
+            return
+        }
+        var state UserResourceModel
+        resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+        if !resp.Diagnostics.HasError() && state.Source.ValueString() == sourceSAML {
+            resp.Diagnostics.AddError("SAML Users Cannot Be Deleted",
+                fmt.Sprintf("User %s is federated from the identity provider and must be removed there. "+
+                    "To stop managing the user, remove it from the configuration with a removed block with destroy = false.", state.Email.ValueString()))
+        }
+        return
+    }
+
+    var plan, config UserResourceModel
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if req.State.Raw.IsNull() {
+        if plan.Source.ValueString() == sourceSAML {
+            resp.Diagnostics.AddAttributeError(path.Root("source"), "SAML Users Cannot Be Created",
+                "Users with source SAML are created by the identity provider when they first sign in. "+
+                    "Adopt an existing federated user with an import block, see the sda_federated_users data source.")
+            return
+        }
+        requireIdPOwned(config, &resp.Diagnostics)
+        return
+    }
+
+    var state UserResourceModel
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // The source is fixed by the Ident Service; an unset source follows the
+    // existing user instead of its default.
+    if config.Source.IsNull() {
+        plan.Source = state.Source
+    } else if !config.Source.IsUnknown() && !config.Source.Equal(state.Source) {
+        resp.Diagnostics.AddAttributeError(path.Root("source"), "User Source Cannot Change",
+            fmt.Sprintf("User %s has source %s, the source of an existing user cannot change.", state.UserID.ValueString(), state.Source.ValueString()))
+        return
+    }
+
+    if state.Source.ValueString() != sourceSAML {
+        requireIdPOwned(config, &resp.Diagnostics)
+        return
+    }
+
+    // Unset attributes keep the values of the identity provider through
+    // UseStateForUnknown, configured ones must match them.
+    stateAttrs := idpOwned(state)
+    for i, a := range idpOwned(config) {
+        if a.value.IsNull() || a.value.IsUnknown() || a.value.Equal(stateAttrs[i].value) {
+            continue
+        }
+        resp.Diagnostics.AddAttributeError(path.Root(a.name), "Attribute Owned By The Identity Provider",
+            fmt.Sprintf("User %s is federated through SAML, its %s is %q at the identity provider and cannot be changed by Terraform. "+
+                "Remove %s from the configuration to follow the identity provider.",
+                state.UserID.ValueString(), a.name, stateAttrs[i].value.ValueString(), a.name))
+    }
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// HELPER FUNCTIONS
+
+type namedString struct {
+    name  string
+    value types.String
+}
+
+// idpOwned returns the attributes of m that the identity provider owns for
+// SAML users.
+func idpOwned(m UserResourceModel) []namedString {
+    return []namedString{
+        {"first_name", m.FirstName},
+        {"last_name", m.LastName},
+        {"email", m.Email},
+    }
+}
+
+// requireIdPOwned reports the identity provider owned attributes missing
+// from config, which users managed by the Ident Service require.
+func requireIdPOwned(config UserResourceModel, diags *diag.Diagnostics) {
+    for _, a := range idpOwned(config) {
+        if a.value.IsNull() {
+            diags.AddAttributeError(path.Root(a.name), "Missing Required Argument",
+                fmt.Sprintf("The argument %q is required for users with source %s.", a.name, sourceSDA))
+        }
+    }
+}
@@ -0,0 +1,173 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// userProvider serves the user resource without a client, which plans do
+// not need.
+type userProvider struct{}
+
+func (p *userProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "sda"
+}
+
+func (p *userProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {}
+
+func (p *userProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {}
+
+func (p *userProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{NewUserResource}
+}
+
+func (p *userProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func TestPlanSAML(t *testing.T) {
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(&userProvider{})()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := schemas.ResourceSchemas["sda_user"]
+	objType := s.ValueType().(tftypes.Object)
+	computed := map[string]bool{}
+	for _, a := range s.Block.Attributes {
+		computed[a.Name] = a.Computed
+	}
+
+	// value returns a user object with the given string attributes, all
+	// other attributes are null.
+	value := func(attrs map[string]string) tftypes.Value {
+		vals := map[string]tftypes.Value{}
+		for name, typ := range objType.AttributeTypes {
+			vals[name] = tftypes.NewValue(typ, nil)
+		}
+		for name, v := range attrs {
+			vals[name] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(objType, vals)
+	}
+	null := tftypes.NewValue(objType, nil)
+
+	// plan plans config against prior the way Terraform does: the proposed
+	// state takes unset computed attributes from prior, and the planned value
+	// of every configured attribute must equal its configuration.
+	plan := func(config, prior tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+		t.Helper()
+		proposed := null
+		if !config.IsNull() {
+			var configAttrs, priorAttrs map[string]tftypes.Value
+			config.As(&configAttrs)
+			if !prior.IsNull() {
+				prior.As(&priorAttrs)
+			}
+			vals := map[string]tftypes.Value{}
+			for name, v := range configAttrs {
+				vals[name] = v
+				if v.IsNull() && computed[name] && priorAttrs != nil {
+					vals[name] = priorAttrs[name]
+				}
+			}
+			proposed = tftypes.NewValue(objType, vals)
+		}
+		dv := func(v tftypes.Value) *tfprotov6.DynamicValue {
+			d, err := tfprotov6.NewDynamicValue(objType, v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &d
+		}
+		resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName:         "sda_user",
+			Config:           dv(config),
+			PriorState:       dv(prior),
+			ProposedNewState: dv(proposed),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				return nil, resp.Diagnostics
+			}
+		}
+		planned, err := resp.PlannedState.Unmarshal(objType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var plannedAttrs, configAttrs map[string]tftypes.Value
+		planned.As(&plannedAttrs)
+		if !config.IsNull() {
+			config.As(&configAttrs)
+		}
+		for name, v := range configAttrs {
+			if !v.IsNull() && !v.Equal(plannedAttrs[name]) {
+				t.Fatalf("planned %s = %s, but it is configured as %s", name, plannedAttrs[name], v)
+			}
+		}
+		return plannedAttrs, resp.Diagnostics
+	}
+	hasError := func(diags []*tfprotov6.Diagnostic, attr string) bool {
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError && (attr == "" || d.Attribute.Equal(tftypes.NewAttributePath().WithAttributeName(attr))) {
+				return true
+			}
+		}
+		return false
+	}
+
+	saml := value(map[string]string{"user_id": "u1", "first_name": "Jane", "last_name": "Doe", "email": "jane@idp.example.com", "source": "SAML"})
+
+	if _, diags := plan(value(map[string]string{"first_name": "Jane", "last_name": "Doe", "email": "jane@idp.example.com", "source": "SAML"}), null); !hasError(diags, "source") {
+		t.Errorf("creating a SAML user: %v", diags)
+	}
+	if _, diags := plan(null, saml); !hasError(diags, "") {
+		t.Errorf("deleting a SAML user: %v", diags)
+	}
+
+	// The identity provider owned attributes and the source are left unset
+	// and follow the existing user.
+	planned, diags := plan(value(map[string]string{}), saml)
+	if hasError(diags, "") {
+		t.Fatalf("adopting a SAML user: %v", diags)
+	}
+	var firstName, source string
+	planned["first_name"].As(&firstName)
+	planned["source"].As(&source)
+	if firstName != "Jane" || source != "SAML" {
+		t.Errorf("planned first_name %q and source %q, want the values of the identity provider", firstName, source)
+	}
+
+	if _, diags := plan(value(map[string]string{"first_name": "Janet"}), saml); !hasError(diags, "first_name") {
+		t.Errorf("renaming a SAML user: %v", diags)
+	}
+	if _, diags := plan(value(map[string]string{"first_name": "Jane", "source": "SDA"}), saml); !hasError(diags, "source") {
+		t.Errorf("changing the source: %v", diags)
+	}
+
+	// Users managed by the Ident Service require the attributes.
+	if _, diags := plan(value(map[string]string{"first_name": "Jane", "last_name": "Doe"}), null); !hasError(diags, "email") {
+		t.Errorf("creating an SDA user without email: %v", diags)
+	}
+	sda := value(map[string]string{"user_id": "u2", "first_name": "Jane", "last_name": "Doe", "email": "jane@example.com", "source": "SDA"})
+	planned, diags = plan(value(map[string]string{"first_name": "Janet", "last_name": "Doe", "email": "jane@example.com"}), sda)
+	if hasError(diags, "") {
+		t.Fatalf("renaming an SDA user: %v", diags)
+	}
+	planned["first_name"].As(&firstName)
+	if firstName != "Janet" {
+		t.Errorf("planned first_name %q, want Janet", firstName)
+	}
+}
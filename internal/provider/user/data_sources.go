@@ -0,0 +1,452 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+	"github.com/sda/terraform-provider-sda/internal/provider/validators"
+)
+
+var (
+	_ datasource.DataSource              = &UserDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserDataSource{}
+	_ datasource.DataSource              = &UsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &UsersDataSource{}
+	_ datasource.DataSource              = &FederatedUsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &FederatedUsersDataSource{}
+)
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+func NewFederatedUsersDataSource() datasource.DataSource {
+	return &FederatedUsersDataSource{}
+}
+
+// UserDataSource looks up a single user by user_id or email.
+type UserDataSource struct {
+	client *clients.Client
+}
+
+// UsersDataSource lists the users of a tenant.
+type UsersDataSource struct {
+	client *clients.Client
+}
+
+// FederatedUsersDataSource lists the users of a tenant federated through SAML,
+// to adopt them as sda_user resources.
+type FederatedUsersDataSource struct {
+	client *clients.Client
+}
+
+// userAttributes returns the computed attributes describing a user.
+func userAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"user_id":              schema.StringAttribute{Computed: true, Description: "Unique identifier for the user."},
+		"email":                schema.StringAttribute{Computed: true, Description: "Email address of the user."},
+		"group_id":             schema.StringAttribute{Computed: true, Description: "Group id of the user."},
+		"first_name":           schema.StringAttribute{Computed: true, Description: "First name of the user."},
+		"last_name":            schema.StringAttribute{Computed: true, Description: "Last name of the user."},
+		"company_name":         schema.StringAttribute{Computed: true, Description: "Company name of the user."},
+		"phone_number":         schema.StringAttribute{Computed: true, Description: "Phone number of the user."},
+		"locale":               schema.StringAttribute{Computed: true, Description: "Locale of the user."},
+		"title":                schema.StringAttribute{Computed: true, Description: "Title of the user."},
+		"source":               schema.StringAttribute{Computed: true, Description: "Source of the user, `SDA` or `SAML`."},
+		"last_login_timestamp": schema.StringAttribute{Computed: true, Description: "Time of the last sign in of the user, null if the user never signed in."},
+		"creation_timestamp":   schema.StringAttribute{Computed: true, Description: "Time the user was created."},
+	}
+}
+
+func tenantIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Tenant to read the users of. Defaults to the tenant of the provider configuration.",
+	}
+}
+
+func (d *UserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := userAttributes()
+	attributes["tenant_id"] = tenantIDAttribute()
+	attributes["user_id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Unique identifier of the user to look up. Exactly one of `user_id` and `email` must be set.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("user_id"), path.MatchRoot("email")),
+		},
+	}
+	attributes["email"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Email address of the user to look up, matched case-insensitively against the users of the tenant.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Looks up a user of the SDA Ident Service by `user_id` or `email`, for example to assign a role to a colleague.",
+		Attributes:  attributes,
+	}
+}
+
+func (d *UserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureClient(req, resp)
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state UserDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(d.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var found *UserAPIResponse
+	if !state.UserID.IsNull() {
+		u, err := getUser(ctx, client, state.UserID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading user %s: %s", state.UserID.ValueString(), err))
+			return
+		}
+		found = u
+	} else {
+		users, err := ListTenantUsers(ctx, client)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error listing users: %s", err))
+			return
+		}
+		for i := range users {
+			if strings.EqualFold(users[i].Email, state.Email.ValueString()) {
+				found = &users[i]
+				break
+			}
+		}
+		if found == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("email"), "User Not Found",
+				fmt.Sprintf("No user with email %s exists in the tenant.", state.Email.ValueString()))
+			return
+		}
+	}
+
+	u := userModel(*found)
+	state.UserID = u.UserID
+	state.Email = u.Email
+	state.GroupID = u.GroupID
+	state.FirstName = u.FirstName
+	state.LastName = u.LastName
+	state.CompanyName = u.CompanyName
+	state.PhoneNumber = u.PhoneNumber
+	state.Locale = u.Locale
+	state.Title = u.Title
+	state.Source = u.Source
+	state.LastLoginTimestamp = u.LastLoginTimestamp
+	state.CreationTimestamp = u.CreationTimestamp
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *UsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the users of a tenant of the SDA Ident Service, optionally filtered. " +
+			"Use `inactive_for` to find stale accounts for access reviews.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": tenantIDAttribute(),
+			"source": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list users of this source, `SDA` or `SAML`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(sourceSDA, sourceSAML),
+				},
+			},
+			"company_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list users of this company, matched case-insensitively.",
+			},
+			"inactive_for": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list users that have not signed in for this duration, such as `2160h`, including users that never signed in.",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"user_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Sorted unique identifiers of the matching users.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching users, sorted by user_id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureClient(req, resp)
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state UsersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(d.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var inactiveFor time.Duration
+	if !state.InactiveFor.IsNull() {
+		var err error
+		if inactiveFor, err = time.ParseDuration(state.InactiveFor.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("inactive_for"), "Invalid Duration", err.Error())
+			return
+		}
+	}
+
+	users, err := ListTenantUsers(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error listing users: %s", err))
+		return
+	}
+
+	filter := userFilter{
+		Source:      state.Source.ValueString(),
+		CompanyName: state.CompanyName.ValueString(),
+		InactiveFor: inactiveFor,
+	}
+	matched := filter.apply(users, time.Now())
+
+	state.UserIDs = make([]string, 0, len(matched))
+	state.Users = make([]UserModel, 0, len(matched))
+	for _, u := range matched {
+		state.UserIDs = append(state.UserIDs, u.UserID)
+		state.Users = append(state.Users, userModel(u))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *FederatedUsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_federated_users"
+}
+
+func (d *FederatedUsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the users of a tenant federated through SAML, to adopt them as `sda_user` resources with `source = \"SAML\"` through import blocks. " +
+			"Federated users are created by the identity provider when they first sign in.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": tenantIDAttribute(),
+			"company_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list users of this company, matched case-insensitively.",
+			},
+			"user_ids_by_email": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Unique identifiers of the federated users by email address, suitable as for_each of an import block.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Federated users, sorted by user_id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *FederatedUsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureClient(req, resp)
+}
+
+func (d *FederatedUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !tenancy.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state FederatedUsersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, diags := tenancy.Client(d.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := ListTenantUsers(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error listing users: %s", err))
+		return
+	}
+
+	matched := userFilter{Source: sourceSAML, CompanyName: state.CompanyName.ValueString()}.apply(users, time.Now())
+
+	state.UserIDsByEmail = make(map[string]string, len(matched))
+	state.Users = make([]UserModel, 0, len(matched))
+	for _, u := range matched {
+		state.UserIDsByEmail[u.Email] = u.UserID
+		state.Users = append(state.Users, userModel(u))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// userFilter selects users of a tenant. Empty fields match every user.
+type userFilter struct {
+	Source      string
+	CompanyName string
+	InactiveFor time.Duration
+}
+
+// apply returns the users that match f at time now, sorted by user_id.
+func (f userFilter) apply(users []UserAPIResponse, now time.Time) []UserAPIResponse {
+	var matched []UserAPIResponse
+	for _, u := range users {
+		if f.Source != "" && u.Source != f.Source {
+			continue
+		}
+		if f.CompanyName != "" && (u.CompanyName == nil || !strings.EqualFold(*u.CompanyName, f.CompanyName)) {
+			continue
+		}
+		if f.InactiveFor > 0 && u.LastLoginTimestamp != nil {
+			// Timestamps that cannot be parsed are treated as never signed in.
+			if last, err := validators.ParseTimestamp(*u.LastLoginTimestamp); err == nil && now.Sub(last) < f.InactiveFor {
+				continue
+			}
+		}
+		matched = append(matched, u)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].UserID < matched[j].UserID })
+	return matched
+}
+
+// userModel converts a user of the API to its data source model.
+func userModel(u UserAPIResponse) UserModel {
+	return UserModel{
+		UserID:             types.StringValue(u.UserID),
+		Email:              types.StringValue(u.Email),
+		GroupID:            types.StringPointerValue(u.GroupID),
+		FirstName:          types.StringValue(u.FirstName),
+		LastName:           types.StringValue(u.LastName),
+		CompanyName:        types.StringPointerValue(u.CompanyName),
+		PhoneNumber:        types.StringPointerValue(u.PhoneNumber),
+		Locale:             types.StringPointerValue(u.Locale),
+		Title:              types.StringPointerValue(u.Title),
+		Source:             types.StringValue(u.Source),
+		LastLoginTimestamp: types.StringPointerValue(u.LastLoginTimestamp),
+		CreationTimestamp:  types.StringValue(u.CreationTimestamp),
+	}
+}
+
+// ListTenantUsers returns the users of the tenant client is scoped to.
+func ListTenantUsers(ctx context.Context, client *clients.Client) ([]UserAPIResponse, error) {
+	tenantID := client.TenantID
+	if tenantID == "" {
+		var tenant struct {
+			TenantID string `json:"tenant_id"`
+		}
+		if err := getJSON(ctx, client, fmt.Sprintf("%s/ident/v1/tenant", client.HostURL), &tenant); err != nil {
+			return nil, fmt.Errorf("reading tenant: %w", err)
+		}
+		tenantID = tenant.TenantID
+	}
+
+	var users []UserAPIResponse
+	if err := getJSON(ctx, client, fmt.Sprintf("%s/ident/v1/tenant/%s/users", client.HostURL, tenantID), &users); err != nil {
+		return nil, fmt.Errorf("listing users of tenant %s: %w", tenantID, err)
+	}
+	return users, nil
+}
+
+func getUser(ctx context.Context, client *clients.Client, userID string) (*UserAPIResponse, error) {
+	var u UserAPIResponse
+	if err := getJSON(ctx, client, fmt.Sprintf("%s/ident/v1/user/%s", client.HostURL, userID), &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func getJSON(ctx context.Context, client *clients.Client, url string, v any) error {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resBody, v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// configureClient returns the provider configured client of a data source.
+func configureClient(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *clients.Client {
+	// ProviderData is nil until the provider has been configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+	return client
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestUserFilter(t *testing.T) {
+	acme, other := "ACME", "Other"
+	recent, stale := "2025-06-01T12:00:00Z", "2025-01-01T12:00:00"
+	users := []UserAPIResponse{
+		{UserID: "u3", Source: "SDA", CompanyName: &acme, LastLoginTimestamp: &recent},
+		{UserID: "u1", Source: "SDA", CompanyName: &acme, LastLoginTimestamp: &stale},
+		{UserID: "u2", Source: "SAML", CompanyName: &other},
+		{UserID: "u4", Source: "SDA"},
+	}
+	now := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	ids := func(users []UserAPIResponse) []string {
+		var ids []string
+		for _, u := range users {
+			ids = append(ids, u.UserID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		filter userFilter
+		want   []string
+	}{
+		{name: "all", want: []string{"u1", "u2", "u3", "u4"}},
+		{name: "source", filter: userFilter{Source: "SDA"}, want: []string{"u1", "u3", "u4"}},
+		{name: "company", filter: userFilter{CompanyName: "acme"}, want: []string{"u1", "u3"}},
+		{name: "inactive", filter: userFilter{InactiveFor: 30 * 24 * time.Hour}, want: []string{"u1", "u2", "u4"}},
+		{name: "combined", filter: userFilter{Source: "SDA", CompanyName: "ACME", InactiveFor: 24 * time.Hour}, want: []string{"u1"}},
+	}
+
+	for _, tt := range tests {
+		if got := ids(tt.filter.apply(users, now)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: apply = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestListTenantUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ident/v1/tenant":
+			w.Write([]byte(`{"tenant_id": "t1"}`))
+		case "/ident/v1/tenant/t1/users":
+			w.Write([]byte(`[{"user_id": "u1", "email": "a@example.com", "source": "SDA"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	users, err := ListTenantUsers(context.Background(), &clients.Client{HostURL: server.URL, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("ListTenantUsers: %s", err)
+	}
+	if len(users) != 1 || users[0].UserID != "u1" {
+		t.Fatalf("users = %+v", users)
+	}
+
+	if _, err := ListTenantUsers(context.Background(), &clients.Client{HostURL: server.URL, HTTPClient: server.Client(), TenantID: "t2"}); err == nil {
+		t.Fatalf("expected an error listing the users of unknown tenant t2")
+	}
+}
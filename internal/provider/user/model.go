@@ -5,6 +5,7 @@ import (
 )
 
 type UserResourceModel struct {
+    TenantID          types.String `tfsdk:"tenant_id"`
     UserID            types.String `tfsdk:"user_id"`
     GroupID           types.String `tfsdk:"group_id"`
     FirstName         types.String `tfsdk:"first_name"`
@@ -49,3 +50,71 @@ type CreateUserAPIResponse struct {
 
 // UserAPIResponse used for read/update responses
 type UserAPIResponse = CreateUserAPIResponse
+
+// UserIdentityModel maps the resource identity schema used by import blocks.
+type UserIdentityModel struct {
+    UserID   types.String `tfsdk:"user_id"`
+    TenantID types.String `tfsdk:"tenant_id"`
+}
+
+// UserModel describes a user returned by the sda_user and sda_users data sources.
+type UserModel struct {
+    UserID             types.String `tfsdk:"user_id"`
+    Email              types.String `tfsdk:"email"`
+    GroupID            types.String `tfsdk:"group_id"`
+    FirstName          types.String `tfsdk:"first_name"`
+    LastName           types.String `tfsdk:"last_name"`
+    CompanyName        types.String `tfsdk:"company_name"`
+    PhoneNumber        types.String `tfsdk:"phone_number"`
+    Locale             types.String `tfsdk:"locale"`
+    Title              types.String `tfsdk:"title"`
+    Source             types.String `tfsdk:"source"`
+    LastLoginTimestamp types.String `tfsdk:"last_login_timestamp"`
+    CreationTimestamp  types.String `tfsdk:"creation_timestamp"`
+}
+
+// UserDataSourceModel maps the sda_user data source schema.
+type UserDataSourceModel struct {
+    TenantID           types.String `tfsdk:"tenant_id"`
+    UserID             types.String `tfsdk:"user_id"`
+    Email              types.String `tfsdk:"email"`
+    GroupID            types.String `tfsdk:"group_id"`
+    FirstName          types.String `tfsdk:"first_name"`
+    LastName           types.String `tfsdk:"last_name"`
+    CompanyName        types.String `tfsdk:"company_name"`
+    PhoneNumber        types.String `tfsdk:"phone_number"`
+    Locale             types.String `tfsdk:"locale"`
+    Title              types.String `tfsdk:"title"`
+    Source             types.String `tfsdk:"source"`
+    LastLoginTimestamp types.String `tfsdk:"last_login_timestamp"`
+    CreationTimestamp  types.String `tfsdk:"creation_timestamp"`
+}
+
+// UsersDataSourceModel maps the sda_users data source schema.
+type UsersDataSourceModel struct {
+    TenantID    types.String `tfsdk:"tenant_id"`
+    Source      types.String `tfsdk:"source"`
+    CompanyName types.String `tfsdk:"company_name"`
+    InactiveFor types.String `tfsdk:"inactive_for"`
+    UserIDs     []string     `tfsdk:"user_ids"`
+    Users       []UserModel  `tfsdk:"users"`
+}
+
+// UserConfirmActionModel maps the sda_user_confirm action schema.
+type UserConfirmActionModel struct {
+    Username         types.String `tfsdk:"username"`
+    ConfirmationCode types.String `tfsdk:"confirmation_code"`
+}
+
+// UserPasswordResetActionModel maps the sda_user_password_reset action schema.
+type UserPasswordResetActionModel struct {
+    Username types.String `tfsdk:"username"`
+}
+
+// FederatedUsersDataSourceModel maps the sda_federated_users data source schema.
+type FederatedUsersDataSourceModel struct {
+    TenantID       types.String      `tfsdk:"tenant_id"`
+    CompanyName    types.String      `tfsdk:"company_name"`
+    UserIDsByEmail map[string]string `tfsdk:"user_ids_by_email"`
+    Users          []UserModel       `tfsdk:"users"`
+}
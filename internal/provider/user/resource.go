@@ -3,18 +3,30 @@ package user
 import (
     "context"
 
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
     "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
     "github.com/sda/terraform-provider-sda/internal/clients"
+    "github.com/sda/terraform-provider-sda/internal/provider/tenancy"
 )
 
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithIdentity = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+
+// Sources of a user, see UserSourceEnum of the Ident Service.
+const (
+    sourceSDA  = "SDA"
+    sourceSAML = "SAML"
+)
 
 func NewUserResource() resource.Resource {
     return &UserResource{}
@@ -30,8 +42,11 @@ func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest,
 
 func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
     resp.Schema = schema.Schema{
-        Description: "Manages a user resource in the SDA Ident Service.",
+        Description: "Manages a user resource in the SDA Ident Service. " +
+            "Users federated through SAML are owned by the identity provider: they cannot be created or deleted by Terraform, only adopted by import with `source = \"SAML\"`, " +
+            "and their `first_name`, `last_name` and `email` are read from the identity provider.",
         Attributes: map[string]schema.Attribute{
+            "tenant_id": tenancy.Attribute(),
             "user_id": schema.StringAttribute{
                 Computed:    true,
                 Description: "Unique identifier for the user.",
@@ -47,16 +62,28 @@ func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
                 },
             },
             "first_name": schema.StringAttribute{
-                Required:    true,
-                Description: "First name of the user.",
+                Optional:    true,
+                Computed:    true,
+                Description: "First name of the user. Required for `SDA` users. Owned by the identity provider for `SAML` users: leave it unset to follow the identity provider.",
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
             },
             "last_name": schema.StringAttribute{
-                Required:    true,
-                Description: "Last name of the user.",
+                Optional:    true,
+                Computed:    true,
+                Description: "Last name of the user. Required for `SDA` users. Owned by the identity provider for `SAML` users: leave it unset to follow the identity provider.",
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
             },
             "email": schema.StringAttribute{
-                Required:    true,
-                Description: "Email address of the user.",
+                Optional:    true,
+                Computed:    true,
+                Description: "Email address of the user. Required for `SDA` users. Owned by the identity provider for `SAML` users: leave it unset to follow the identity provider.",
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
             },
             "company_name": schema.StringAttribute{
                 Optional:    true,
@@ -116,9 +143,14 @@ func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
                 Description: "Date and time when this object was last modified (ISO 8601).",
             },
             "source": schema.StringAttribute{
+                Optional:    true,
                 Computed:    true,
-                Description: "Source of the user (SDA or SAML).",
-                Default:     stringdefault.StaticString("SDA"),
+                Description: "Source of the user, `SDA` for users managed by the Ident Service or `SAML` for users federated from an identity provider. Defaults to `SDA`, or to the source of an imported user. " +
+                    "Only `SDA` users can be created; set `SAML` when adopting a federated user so that the attributes owned by the identity provider are not managed.",
+                Default:     stringdefault.StaticString(sourceSDA),
+                Validators: []validator.String{
+                    stringvalidator.OneOf(sourceSDA, sourceSAML),
+                },
             },
         },
     }
@@ -131,6 +163,18 @@ func (r *UserResource) Configure(_ context.Context, req resource.ConfigureReques
     r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *UserResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+    resp.IdentitySchema = identityschema.Schema{
+        Attributes: map[string]identityschema.Attribute{
+            "tenant_id": tenancy.IdentityAttribute(),
+            "user_id": identityschema.StringAttribute{
+                RequiredForImport: true,
+                Description:       "Unique identifier for the user.",
+            },
+        },
+    }
+}
+
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-    resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
+    tenancy.ImportStatePassthrough(ctx, path.Root("user_id"), req, resp)
 }
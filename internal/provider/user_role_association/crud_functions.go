@@ -7,9 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+	"github.com/sda/terraform-provider-sda/internal/provider/validators"
 )
 
 // Ensure UserRoleAssociationResource implements CRUD interfaces
@@ -17,20 +24,37 @@ var _ resource.Resource = &UserRoleAssociationResource{}
 
 // CREATE
 func (r *UserRoleAssociationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan UserRoleAssociationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, plan.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Build URL
-	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", r.client.HostURL, plan.UserID.ValueString(), plan.UserRoleID.ValueString())
+	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", client.HostURL, plan.UserID.ValueString(), plan.UserRoleID.ValueString())
 
 	// Create Payload body
 	payload := map[string]string{}
 
-	if !plan.ExpirationTimestamp.IsUnknown() && !plan.ExpirationTimestamp.IsNull() {
-		payload["expiration_timestamp"] = plan.ExpirationTimestamp.ValueString()
+	expiration := plan.ExpirationTimestamp
+	if !plan.Duration.IsNull() && !plan.Duration.IsUnknown() {
+		var err error
+		if expiration, err = expirationFromDuration(plan.Duration.ValueString(), time.Now()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid duration: %s", err))
+			return
+		}
+	}
+	if !expiration.IsUnknown() && !expiration.IsNull() {
+		payload["expiration_timestamp"] = expiration.ValueString()
 	}
 
 	body, err := json.Marshal(payload)
@@ -39,14 +63,14 @@ func (r *UserRoleAssociationResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
-	reqHTTP, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating request: %s", err))
 		return
 	}
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
-	resBody, err := r.client.DoRequest(reqHTTP, nil)
+	resBody, err := client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		errSummary := "API Error"
 		if strings.Contains(err.Error(), "status: 403") {
@@ -70,6 +94,7 @@ func (r *UserRoleAssociationResource) Create(ctx context.Context, req resource.C
 	}
 
 	state := UserRoleAssociationResourceModel{
+		TenantID:            plan.TenantID,
 		UserID:              types.StringValue(apiResp.UserID),
 		UserRoleID:          types.StringValue(apiResp.UserRoleId),
 		ObjectVersion:       types.Int64Value(apiResp.ObjectVersion),
@@ -77,29 +102,45 @@ func (r *UserRoleAssociationResource) Create(ctx context.Context, req resource.C
 		UpdateUserID:        types.StringPointerValue(apiResp.UpdateUserID),
 		CreationTimestamp:   types.StringValue(apiResp.CreationTimestamp),
 		UpdateTimestamp:     types.StringPointerValue(apiResp.UpdateTimestamp),
-		ExpirationTimestamp: types.StringPointerValue(apiResp.ExpirationTimestamp),
+		ExpirationTimestamp: reconcileExpiration(expiration, apiResp.ExpirationTimestamp),
+		Duration:            plan.Duration,
+		Expired:             plan.Expired,
+	}
+	if state.Expired.IsUnknown() {
+		state.Expired = types.BoolValue(isExpired(state.ExpirationTimestamp, time.Now()))
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, UserRoleAssociationIdentityModel{UserID: state.UserID, UserRoleID: state.UserRoleID, TenantID: state.TenantID})...)
 }
 
 // READ
 func (r *UserRoleAssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state UserRoleAssociationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, UserRoleAssociationIdentityModel{UserID: state.UserID, UserRoleID: state.UserRoleID, TenantID: state.TenantID})...)
 
-	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", r.client.HostURL, state.UserID.ValueString(), state.UserRoleID.ValueString())
+	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", client.HostURL, state.UserID.ValueString(), state.UserRoleID.ValueString())
 
-	reqHTTP, err := http.NewRequest(http.MethodGet, url, nil)
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating read request: %s", err))
 		return
 	}
 
-	resBody, err := r.client.DoRequest(reqHTTP, nil)
+	resBody, err := client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "status: 404") {
 			resp.State.RemoveResource(ctx)
@@ -128,19 +169,39 @@ func (r *UserRoleAssociationResource) Read(ctx context.Context, req resource.Rea
 	state.UpdateTimestamp = types.StringPointerValue(apiResp.UpdateTimestamp)
 	state.UserID = types.StringValue(apiResp.UserID)
 	state.UserRoleID = types.StringValue(apiResp.UserRoleId)
-	state.ExpirationTimestamp = types.StringPointerValue(apiResp.ExpirationTimestamp)
+	state.ExpirationTimestamp = reconcileExpiration(state.ExpirationTimestamp, apiResp.ExpirationTimestamp)
+
+	// Links created from a duration are renewed in place by the next plan,
+	// other expired links are flagged until their expiration is changed.
+	expired := isExpired(state.ExpirationTimestamp, time.Now())
+	if expired && !state.Duration.IsNull() {
+		tflog.Info(ctx, "Role assignment expired, it is renewed from its duration", map[string]any{"user_id": state.UserID.ValueString(), "user_role_id": state.UserRoleID.ValueString()})
+	} else if expired {
+		resp.Diagnostics.AddWarning("Role Assignment Expired",
+			fmt.Sprintf("The assignment of role %s to user %s expired at %s. Change expiration_timestamp to renew it or remove the resource.", state.UserRoleID.ValueString(), state.UserID.ValueString(), state.ExpirationTimestamp.ValueString()))
+	}
+	state.Expired = types.BoolValue(expired)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 // UPDATE
 func (r *UserRoleAssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan, state UserRoleAssociationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, plan.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if !plan.UserID.Equal(state.UserID) || !plan.UserRoleID.Equal(state.UserRoleID) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("User role associated to a user can't be updated"))
@@ -151,11 +212,19 @@ func (r *UserRoleAssociationResource) Update(ctx context.Context, req resource.U
 		"object_version": state.ObjectVersion.ValueInt64(),
 	}
 
-	if !plan.ExpirationTimestamp.Equal(state.ExpirationTimestamp) {
-		if plan.ExpirationTimestamp.IsNull() {
+	expiration := plan.ExpirationTimestamp
+	if expiration.IsUnknown() && !plan.Duration.IsNull() && !plan.Duration.IsUnknown() {
+		var err error
+		if expiration, err = expirationFromDuration(plan.Duration.ValueString(), time.Now()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid duration: %s", err))
+			return
+		}
+	}
+	if !expiration.Equal(state.ExpirationTimestamp) {
+		if expiration.IsNull() {
 			payload["expiration_timestamp"] = nil
-		} else if !plan.ExpirationTimestamp.IsUnknown() {
-			payload["expiration_timestamp"] = plan.ExpirationTimestamp.ValueString()
+		} else if !expiration.IsUnknown() {
+			payload["expiration_timestamp"] = expiration.ValueString()
 		}
 	}
 
@@ -165,15 +234,15 @@ func (r *UserRoleAssociationResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
-	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", r.client.HostURL, state.UserID.ValueString(), state.UserRoleID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", client.HostURL, state.UserID.ValueString(), state.UserRoleID.ValueString())
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating update request: %s", err))
 		return
 	}
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
-	resBody, err := r.client.DoRequest(reqHTTP, nil)
+	resBody, err := client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "status: 404") {
 			resp.State.RemoveResource(ctx)
@@ -202,27 +271,42 @@ func (r *UserRoleAssociationResource) Update(ctx context.Context, req resource.U
 	// Keep configured attributes aligned with the applied plan.
 	state.UserID = plan.UserID
 	state.UserRoleID = plan.UserRoleID
-	state.ExpirationTimestamp = plan.ExpirationTimestamp
+	state.ExpirationTimestamp = expiration
+	state.Duration = plan.Duration
+	state.Expired = plan.Expired
+	if state.Expired.IsUnknown() {
+		state.Expired = types.BoolValue(isExpired(state.ExpirationTimestamp, time.Now()))
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, UserRoleAssociationIdentityModel{UserID: state.UserID, UserRoleID: state.UserRoleID, TenantID: state.TenantID})...)
 }
 
 // DELETE
 func (r *UserRoleAssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !tenancy.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state UserRoleAssociationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := tenancy.Client(r.client, state.TenantID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", r.client.HostURL, state.UserID.ValueString(), state.UserRoleID.ValueString())
-	reqHTTP, err := http.NewRequest(http.MethodDelete, url, nil)
+	url := fmt.Sprintf("%s/ident/v1/user_role_user_link/user/%s/user_role/%s", client.HostURL, state.UserID.ValueString(), state.UserRoleID.ValueString())
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating delete request: %s", err))
 		return
 	}
 
-	_, err = r.client.DoRequest(reqHTTP, nil)
+	_, err = client.DoRequest(reqHTTP, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "status: 404") {
 			return
@@ -230,3 +314,163 @@ func (r *UserRoleAssociationResource) Delete(ctx context.Context, req resource.D
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error deleting user-role link: %s", err))
 	}
 }
+
+// PLAN MODIFICATION
+
+// ModifyPlan computes expiration_timestamp and expired, and rejects new
+// expirations that lie in the past or beyond the max_user_role_expiration_days
+// of the tenant.
+func (r *UserRoleAssociationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config UserRoleAssociationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	var state *UserRoleAssociationResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &UserRoleAssociationResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	// An expired link created from a duration is renewed by resolving its
+	// duration again.
+	durationChanged := !plan.Duration.IsNull() && (state == nil || !plan.Duration.Equal(state.Duration) || isExpired(state.ExpirationTimestamp, now))
+
+	// A duration is resolved against the apply time, so the timestamp is only
+	// known after apply unless the duration is unchanged.
+	switch {
+	case durationChanged || plan.Duration.IsUnknown():
+		plan.ExpirationTimestamp = types.StringUnknown()
+	case !plan.Duration.IsNull():
+		plan.ExpirationTimestamp = state.ExpirationTimestamp
+	case config.ExpirationTimestamp.IsNull():
+		plan.ExpirationTimestamp = types.StringNull()
+	}
+
+	switch {
+	case !plan.Duration.IsNull() && plan.ExpirationTimestamp.IsUnknown():
+		plan.Expired = types.BoolValue(false)
+	case plan.ExpirationTimestamp.IsUnknown():
+		plan.Expired = types.BoolUnknown()
+	default:
+		plan.Expired = types.BoolValue(isExpired(plan.ExpirationTimestamp, now))
+	}
+
+	var expiry time.Time
+	attribute := path.Root("expiration_timestamp")
+	switch {
+	case durationChanged && !plan.Duration.IsUnknown():
+		d, err := time.ParseDuration(plan.Duration.ValueString())
+		if err != nil {
+			// Reported by the attribute validator.
+			return
+		}
+		expiry = now.Add(d)
+		attribute = path.Root("duration")
+	case !config.ExpirationTimestamp.IsNull() && !config.ExpirationTimestamp.IsUnknown() && (state == nil || !sameInstant(state.ExpirationTimestamp, config.ExpirationTimestamp)):
+		t, err := time.Parse(time.RFC3339, config.ExpirationTimestamp.ValueString())
+		if err != nil {
+			// Reported by the attribute validator.
+			return
+		}
+		if !t.After(now) {
+			resp.Diagnostics.AddAttributeError(attribute, "Expiration In The Past",
+				fmt.Sprintf("expiration_timestamp %s has already passed.", config.ExpirationTimestamp.ValueString()))
+			return
+		}
+		expiry = t
+	}
+
+	if !expiry.IsZero() && r.client != nil {
+		client, diags := tenancy.Client(r.client, plan.TenantID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		maxDays, err := maxExpirationDays(ctx, client)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to read the tenant expiration limit", map[string]any{"error": err.Error()})
+		} else if maxDays != nil && expiry.After(now.AddDate(0, 0, int(*maxDays))) {
+			resp.Diagnostics.AddAttributeError(attribute, "Expiration Exceeds Tenant Limit",
+				fmt.Sprintf("The tenant allows role assignments of at most %d days, but this assignment would expire at %s.", *maxDays, expiry.UTC().Format(time.RFC3339)))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// HELPER FUNCTIONS
+
+// maxExpirationDays returns the max_user_role_expiration_days of the tenant,
+// or nil when the tenant does not limit role assignments.
+func maxExpirationDays(ctx context.Context, client *clients.Client) (*int64, error) {
+	reqHTTP, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/ident/v1/tenant", client.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := client.DoRequest(reqHTTP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenant tenantExpirationLimit
+	if err := json.Unmarshal(resBody, &tenant); err != nil {
+		return nil, err
+	}
+	return tenant.MaxUserRoleExpirationDays, nil
+}
+
+// expirationFromDuration returns the RFC 3339 timestamp duration after now.
+func expirationFromDuration(duration string, now time.Time) (types.String, error) {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(now.Add(d).UTC().Format(time.RFC3339)), nil
+}
+
+// reconcileExpiration returns the expiration_timestamp to store for the value
+// returned by the API. The known value is kept when both denote the same
+// instant, so that the API's timestamp format does not cause a diff.
+func reconcileExpiration(known types.String, api *string) types.String {
+	if api == nil || *api == "" {
+		return types.StringNull()
+	}
+	if sameInstant(known, types.StringValue(*api)) {
+		return known
+	}
+	return types.StringValue(*api)
+}
+
+// sameInstant reports whether a and b are timestamps of the same instant.
+func sameInstant(a, b types.String) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return a.Equal(b)
+	}
+	ta, errA := validators.ParseTimestamp(a.ValueString())
+	tb, errB := validators.ParseTimestamp(b.ValueString())
+	if errA != nil || errB != nil {
+		return a.Equal(b)
+	}
+	return ta.Equal(tb)
+}
+
+// isExpired reports whether the expiration timestamp ts lies at or before now.
+func isExpired(ts types.String, now time.Time) bool {
+	if ts.IsNull() || ts.IsUnknown() {
+		return false
+	}
+	t, err := validators.ParseTimestamp(ts.ValueString())
+	if err != nil {
+		return false
+	}
+	return !t.After(now)
+}
@@ -0,0 +1,162 @@
+package user_role_association
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+func TestReconcileExpiration(t *testing.T) {
+	known := types.StringValue("2030-01-01T01:00:00+01:00")
+
+	if got := reconcileExpiration(known, ptr("2030-01-01T00:00:00")); !got.Equal(known) {
+		t.Errorf("same instant in another format should keep %s, got %s", known, got)
+	}
+	if got := reconcileExpiration(known, ptr("2030-01-02T00:00:00Z")); got.ValueString() != "2030-01-02T00:00:00Z" {
+		t.Errorf("changed expiration should be taken from the API, got %s", got)
+	}
+	if got := reconcileExpiration(known, nil); !got.IsNull() {
+		t.Errorf("removed expiration should be null, got %s", got)
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]bool{
+		"2029-12-31T23:59:59Z":      true,
+		"2030-01-01T00:00:00Z":      true,
+		"2030-01-01T00:00:01Z":      false,
+		"2030-01-01T00:30:00+01:00": true,
+	}
+	for ts, want := range cases {
+		if got := isExpired(types.StringValue(ts), now); got != want {
+			t.Errorf("isExpired(%s) = %v, want %v", ts, got, want)
+		}
+	}
+	if isExpired(types.StringNull(), now) {
+		t.Error("links without expiration never expire")
+	}
+}
+
+func TestModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tenant_id": "t1", "max_user_role_expiration_days": 7}`))
+	}))
+	defer server.Close()
+
+	r := &UserRoleAssociationResource{client: &clients.Client{HostURL: server.URL, HTTPClient: server.Client()}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	newPlan := func(m UserRoleAssociationResourceModel) tfsdk.Plan {
+		p := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := p.Set(ctx, &m); diags.HasError() {
+			t.Fatalf("building plan: %v", diags)
+		}
+		return p
+	}
+	model := func(expiration, duration types.String) UserRoleAssociationResourceModel {
+		return UserRoleAssociationResourceModel{
+			UserID:              types.StringValue("u1"),
+			UserRoleID:          types.StringValue("r1"),
+			ExpirationTimestamp: expiration,
+			Duration:            duration,
+			Expired:             types.BoolUnknown(),
+			ObjectVersion:       types.Int64Unknown(),
+			CreationUserID:      types.StringUnknown(),
+			UpdateUserID:        types.StringUnknown(),
+			CreationTimestamp:   types.StringUnknown(),
+			UpdateTimestamp:     types.StringUnknown(),
+		}
+	}
+	emptyState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	// applied returns the state of a link applied with expiration and duration.
+	applied := func(expiration, duration types.String) *UserRoleAssociationResourceModel {
+		m := model(expiration, duration)
+		m.Expired = types.BoolValue(isExpired(expiration, time.Now()))
+		m.ObjectVersion = types.Int64Value(1)
+		m.CreationUserID = types.StringValue("admin")
+		m.UpdateUserID = types.StringNull()
+		m.CreationTimestamp = types.StringValue("2025-01-01T00:00:00Z")
+		m.UpdateTimestamp = types.StringNull()
+		return &m
+	}
+	future := types.StringValue(time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339))
+
+	cases := []struct {
+		name       string
+		config     UserRoleAssociationResourceModel
+		state      *UserRoleAssociationResourceModel
+		wantErr    string
+		wantExpiry string // "unknown", "null", "state" or empty when not checked
+	}{
+		{name: "duration within limit", config: model(types.StringNull(), types.StringValue("72h")), wantExpiry: "unknown"},
+		{name: "duration beyond limit", config: model(types.StringNull(), types.StringValue("240h")), wantErr: "Expiration Exceeds Tenant Limit"},
+		{name: "timestamp beyond limit", config: model(types.StringValue(time.Now().AddDate(0, 0, 30).UTC().Format(time.RFC3339)), types.StringNull()), wantErr: "Expiration Exceeds Tenant Limit"},
+		{name: "timestamp in the past", config: model(types.StringValue("2020-01-01T00:00:00Z"), types.StringNull()), wantErr: "Expiration In The Past"},
+		{name: "no expiration", config: model(types.StringNull(), types.StringNull()), wantExpiry: "null"},
+		{name: "duration not expired", config: model(types.StringNull(), types.StringValue("72h")), state: applied(future, types.StringValue("72h")), wantExpiry: "state"},
+		{name: "duration expired", config: model(types.StringNull(), types.StringValue("72h")), state: applied(types.StringValue("2020-01-01T00:00:00Z"), types.StringValue("72h")), wantExpiry: "unknown"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			plan := newPlan(c.config)
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}
+			state := emptyState
+			if c.state != nil {
+				state = tfsdk.State{Schema: schemaResp.Schema, Raw: newPlan(*c.state).Raw}
+				// Computed attributes are planned from the state.
+				prior := *c.state
+				prior.ExpirationTimestamp = types.StringUnknown()
+				prior.Expired = types.BoolUnknown()
+				plan = newPlan(prior)
+			}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: config, State: state}, resp)
+
+			if c.wantErr != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Summary(), c.wantErr) {
+					t.Fatalf("expected %q error, got %v", c.wantErr, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got UserRoleAssociationResourceModel
+			resp.Plan.Get(ctx, &got)
+			switch c.wantExpiry {
+			case "unknown":
+				if !got.ExpirationTimestamp.IsUnknown() || !got.Expired.Equal(types.BoolValue(false)) {
+					t.Errorf("expected unknown expiration that is not expired, got %s / %s", got.ExpirationTimestamp, got.Expired)
+				}
+			case "state":
+				if !got.ExpirationTimestamp.Equal(c.state.ExpirationTimestamp) || !got.Expired.Equal(types.BoolValue(false)) {
+					t.Errorf("expected the expiration of the state, got %s / %s", got.ExpirationTimestamp, got.Expired)
+				}
+			case "null":
+				if !got.ExpirationTimestamp.IsNull() || !got.Expired.Equal(types.BoolValue(false)) {
+					t.Errorf("expected null expiration that is not expired, got %s / %s", got.ExpirationTimestamp, got.Expired)
+				}
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
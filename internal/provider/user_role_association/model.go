@@ -6,9 +6,12 @@ import (
 
 // ResourceModel maps resource schema attributes to Go types for CRUD operations.
 type UserRoleAssociationResourceModel struct {
+	TenantID            types.String `tfsdk:"tenant_id"`
 	UserID              types.String `tfsdk:"user_id"`
 	UserRoleID          types.String `tfsdk:"user_role_id"`
 	ExpirationTimestamp types.String `tfsdk:"expiration_timestamp"`
+	Duration            types.String `tfsdk:"duration"`
+	Expired             types.Bool   `tfsdk:"expired"`
 	ObjectVersion       types.Int64  `tfsdk:"object_version"`
 	CreationUserID      types.String `tfsdk:"creation_user_id"`
 	UpdateUserID        types.String `tfsdk:"update_user_id"`
@@ -30,3 +33,15 @@ type CreateUserRoleAssociationAPIResponse struct {
 }
 
 type UserRoleAssociationAPIResponse = CreateUserRoleAssociationAPIResponse
+
+// UserRoleAssociationIdentityModel maps the resource identity schema used by import blocks.
+type UserRoleAssociationIdentityModel struct {
+	UserID     types.String `tfsdk:"user_id"`
+	UserRoleID types.String `tfsdk:"user_role_id"`
+	TenantID   types.String `tfsdk:"tenant_id"`
+}
+
+// tenantExpirationLimit holds the tenant setting that bounds expiration_timestamp.
+type tenantExpirationLimit struct {
+	MaxUserRoleExpirationDays *int64 `json:"max_user_role_expiration_days"`
+}
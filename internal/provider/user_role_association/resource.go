@@ -2,15 +2,27 @@ package user_role_association
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
+	"github.com/sda/terraform-provider-sda/internal/provider/tenancy"
+	"github.com/sda/terraform-provider-sda/internal/provider/validators"
 )
 
 var _ resource.Resource = &UserRoleAssociationResource{}
 var _ resource.ResourceWithImportState = &UserRoleAssociationResource{}
+var _ resource.ResourceWithIdentity = &UserRoleAssociationResource{}
+var _ resource.ResourceWithModifyPlan = &UserRoleAssociationResource{}
 
 func NewUserRoleAssociationResource() resource.Resource {
 	return &UserRoleAssociationResource{}
@@ -28,17 +40,40 @@ func (r *UserRoleAssociationResource) Schema(_ context.Context, _ resource.Schem
 	resp.Schema = schema.Schema{
 		Description: "Link a user with a user role in the SDA Ident Service.",
 		Attributes: map[string]schema.Attribute{
+			"tenant_id": tenancy.Attribute(),
 			"user_id": schema.StringAttribute{
 				Required:    true,
 				Description: "Unique identifier for the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"user_role_id": schema.StringAttribute{
 				Required:    true,
 				Description: "Unique identifier for the user role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"expiration_timestamp": schema.StringAttribute{
 				Optional:    true,
-				Description: "Optional expiration date and time for this link (ISO 8601 format).",
+				Computed:    true,
+				Description: "Optional expiration date and time for this link (RFC 3339 format). Must not exceed the `max_user_role_expiration_days` of the tenant.",
+				Validators: []validator.String{
+					validators.RFC3339(),
+				},
+			},
+			"duration": schema.StringAttribute{
+				Optional:    true,
+				Description: "Optional lifetime of the link, for example `72h`. `expiration_timestamp` is computed from the apply time, and once the link has expired the next apply renews it in place with a new expiration, which suits break-glass access.",
+				Validators: []validator.String{
+					validators.Duration(),
+					stringvalidator.ConflictsWith(path.MatchRoot("expiration_timestamp")),
+				},
+			},
+			"expired": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether `expiration_timestamp` has passed. Expired links with `duration` are renewed by the next apply, expired links without it stay in state so that they can be renewed or removed.",
 			},
 			"object_version": schema.Int64Attribute{
 				Computed:    true,
@@ -71,6 +106,52 @@ func (r *UserRoleAssociationResource) Configure(_ context.Context, req resource.
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *UserRoleAssociationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"tenant_id": tenancy.IdentityAttribute(),
+			"user_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the user.",
+			},
+			"user_role_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the user role.",
+			},
+		},
+	}
+}
+
 func (r *UserRoleAssociationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("user_role_id"), req, resp)
+	var identity UserRoleAssociationIdentityModel
+
+	if req.ID != "" {
+		// Import format: [tenant:<tenant_id>/]user_id/user_role_id
+		tenantID, id, err := tenancy.SplitImportID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		idParts, err := importid.Split(id, 2)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected import ID format: user_id/user_role_id, got: %s (%s)", req.ID, err),
+			)
+			return
+		}
+		identity.TenantID = tenantID
+		identity.UserID = types.StringValue(idParts[0])
+		identity.UserRoleID = types.StringValue(idParts[1])
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), identity.TenantID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), identity.UserID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_role_id"), identity.UserRoleID)...)
 }
@@ -0,0 +1,94 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ConfigValidator = gatewayInSubnetValidator{}
+
+// GatewayInSubnet returns a resource config validator that checks the
+// subnet_mask of the connection object at p fits the address family of its
+// ip_address, and that its gateway_ip_address lies inside the network they
+// describe.
+func GatewayInSubnet(p path.Path) resource.ConfigValidator {
+	return gatewayInSubnetValidator{path: p}
+}
+
+type gatewayInSubnetValidator struct {
+	path path.Path
+}
+
+func (v gatewayInSubnetValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("%s.subnet_mask must fit the address family of ip_address, and %s.gateway_ip_address must be inside the network they describe", v.path, v.path)
+}
+
+func (v gatewayInSubnetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v gatewayInSubnetValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ipAddress, subnetMask, gateway types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.path.AtName("ip_address"), &ipAddress)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.path.AtName("subnet_mask"), &subnetMask)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.path.AtName("gateway_ip_address"), &gateway)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ipAddress.IsNull() || ipAddress.IsUnknown() || subnetMask.IsNull() || subnetMask.IsUnknown() {
+		return
+	}
+
+	// Malformed values are reported by the attribute validators.
+	addr, err := ParseIPAddress(ipAddress.ValueString())
+	if err != nil {
+		return
+	}
+	bits, err := ParsePrefixLength(subnetMask.ValueString(), addr.BitLen())
+	if err != nil {
+		// The attribute validator accepts prefix lengths up to 128, as it
+		// does not know the address family.
+		if _, wideErr := ParsePrefixLength(subnetMask.ValueString(), 128); wideErr == nil {
+			resp.Diagnostics.AddAttributeError(
+				v.path.AtName("subnet_mask"),
+				"Invalid Subnet Mask",
+				fmt.Sprintf("Subnet mask %s does not fit the device address %s: %s", subnetMask.ValueString(), addr, err),
+			)
+		}
+		return
+	}
+
+	if gateway.IsNull() || gateway.IsUnknown() {
+		return
+	}
+	gw, err := ParseIPAddress(gateway.ValueString())
+	if err != nil {
+		return
+	}
+
+	if addr.Is4() != gw.Is4() {
+		resp.Diagnostics.AddAttributeError(
+			v.path.AtName("gateway_ip_address"),
+			"Gateway Address Family Mismatch",
+			fmt.Sprintf("Gateway %s and device address %s must both be IPv4 or both be IPv6.", gw, addr),
+		)
+		return
+	}
+
+	prefix := netip.PrefixFrom(addr, bits).Masked()
+	if !prefix.Contains(gw) {
+		resp.Diagnostics.AddAttributeError(
+			v.path.AtName("gateway_ip_address"),
+			"Gateway Outside Subnet",
+			fmt.Sprintf("Gateway %s is not inside the device network %s (ip_address %s, subnet_mask %s).",
+				gw, prefix, addr, subnetMask.ValueString()),
+		)
+	}
+}
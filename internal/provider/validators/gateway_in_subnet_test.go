@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestGatewayInSubnet(t *testing.T) {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"connection_configuration": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"ip_address":         schema.StringAttribute{Required: true},
+					"subnet_mask":        schema.StringAttribute{Optional: true},
+					"gateway_ip_address": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+
+	connType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"ip_address":         tftypes.String,
+		"subnet_mask":        tftypes.String,
+		"gateway_ip_address": tftypes.String,
+	}}
+
+	cases := []struct {
+		ip, mask, gateway string
+		wantErr           bool
+		// maskErr expects the error on subnet_mask.
+		maskErr bool
+	}{
+		{ip: "192.168.1.10", mask: "255.255.255.0", gateway: "192.168.1.1"},
+		{ip: "192.168.1.10", mask: "24", gateway: "192.168.2.1", wantErr: true},
+		{ip: "10.0.0.5", mask: "255.255.0.0", gateway: "10.0.255.254"},
+		{ip: "2001:db8::10", mask: "/64", gateway: "2001:db8::1"},
+		{ip: "2001:db8::10", mask: "64", gateway: "2001:db8:1::1", wantErr: true},
+		{ip: "192.168.1.10", mask: "24", gateway: "2001:db8::1", wantErr: true},
+		{ip: "192.168.1.10", mask: "64", gateway: "192.168.1.1", wantErr: true, maskErr: true},
+		{ip: "192.168.1.10", mask: "/33", gateway: "192.168.1.1", wantErr: true, maskErr: true},
+		{ip: "2001:db8::10", mask: "128", gateway: "2001:db8::10"},
+	}
+
+	for _, c := range cases {
+		raw := tftypes.NewValue(
+			tftypes.Object{AttributeTypes: map[string]tftypes.Type{"connection_configuration": connType}},
+			map[string]tftypes.Value{
+				"connection_configuration": tftypes.NewValue(connType, map[string]tftypes.Value{
+					"ip_address":         tftypes.NewValue(tftypes.String, c.ip),
+					"subnet_mask":        tftypes.NewValue(tftypes.String, c.mask),
+					"gateway_ip_address": tftypes.NewValue(tftypes.String, c.gateway),
+				}),
+			},
+		)
+
+		resp := &resource.ValidateConfigResponse{}
+		GatewayInSubnet(path.Root("connection_configuration")).ValidateResource(
+			context.Background(),
+			resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: raw}},
+			resp,
+		)
+
+		if resp.Diagnostics.HasError() != c.wantErr {
+			t.Errorf("%s/%s via %s: expected error=%t, got diagnostics: %v", c.ip, c.mask, c.gateway, c.wantErr, resp.Diagnostics)
+			continue
+		}
+		if c.maskErr {
+			want := path.Root("connection_configuration").AtName("subnet_mask")
+			if d, ok := resp.Diagnostics[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(want) {
+				t.Errorf("%s/%s: expected the error on %s, got diagnostics: %v", c.ip, c.mask, want, resp.Diagnostics)
+			}
+		}
+	}
+}
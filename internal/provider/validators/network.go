@@ -0,0 +1,127 @@
+// Package validators holds schema and configuration validators shared by the
+// SDA resources.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ validator.String = ipAddressValidator{}
+	_ validator.String = subnetMaskValidator{}
+)
+
+// IPAddress returns a validator that accepts a single IPv4 or IPv6 address.
+func IPAddress() validator.String {
+	return ipAddressValidator{}
+}
+
+// SubnetMask returns a validator that accepts either a dotted-decimal IPv4
+// subnet mask (255.255.255.0) or a prefix length (24 or /24).
+func SubnetMask() validator.String {
+	return subnetMaskValidator{}
+}
+
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be a valid IPv4 or IPv6 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := ParseIPAddress(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			fmt.Sprintf("Attribute %s %s, got: %q. %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+type subnetMaskValidator struct{}
+
+func (v subnetMaskValidator) Description(_ context.Context) string {
+	return "value must be a dotted-decimal subnet mask (255.255.255.0) or a prefix length (24 or /24)"
+}
+
+func (v subnetMaskValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v subnetMaskValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// The address family is unknown here, so accept the widest prefix length.
+	if _, err := ParsePrefixLength(req.ConfigValue.ValueString(), 128); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Subnet Mask",
+			fmt.Sprintf("Attribute %s %s, got: %q. %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// ParseIPAddress parses a plain IPv4 or IPv6 address. Zoned addresses and
+// CIDR notation are rejected.
+func ParseIPAddress(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("zoned addresses are not supported")
+	}
+	return addr.Unmap(), nil
+}
+
+// ParsePrefixLength converts a subnet mask into a prefix length no larger than
+// maxBits. Dotted-decimal masks must have contiguous leading ones.
+func ParsePrefixLength(s string, maxBits int) (int, error) {
+	if strings.Contains(s, ".") {
+		mask, err := netip.ParseAddr(s)
+		if err != nil || !mask.Is4() {
+			return 0, fmt.Errorf("%q is not a dotted-decimal IPv4 mask", s)
+		}
+
+		bits := 0
+		seenZero := false
+		for _, b := range mask.AsSlice() {
+			for i := 7; i >= 0; i-- {
+				if b&(1<<i) != 0 {
+					if seenZero {
+						return 0, fmt.Errorf("%q is not a contiguous subnet mask", s)
+					}
+					bits++
+				} else {
+					seenZero = true
+				}
+			}
+		}
+		return bits, nil
+	}
+
+	bits, err := strconv.Atoi(strings.TrimPrefix(s, "/"))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a prefix length", s)
+	}
+	if bits < 0 || bits > maxBits {
+		return 0, fmt.Errorf("prefix length must be between 0 and %d", maxBits)
+	}
+	return bits, nil
+}
@@ -0,0 +1,62 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIPAddressValidator(t *testing.T) {
+	cases := map[string]bool{
+		"192.168.0.10": true,
+		"fe80::1":      true,
+		"256.1.1.1":    false,
+		"10.0.0.0/24":  false,
+		"fe80::1%eth0": false,
+		"plc-01":       false,
+	}
+
+	for value, valid := range cases {
+		resp := &validator.StringResponse{}
+		IPAddress().ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("ip_address"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("IPAddress(%q): expected valid=%t, got diagnostics: %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestParsePrefixLength(t *testing.T) {
+	cases := []struct {
+		value   string
+		maxBits int
+		want    int
+		wantErr bool
+	}{
+		{value: "255.255.255.0", maxBits: 32, want: 24},
+		{value: "255.255.254.0", maxBits: 32, want: 23},
+		{value: "0.0.0.0", maxBits: 32, want: 0},
+		{value: "24", maxBits: 32, want: 24},
+		{value: "/64", maxBits: 128, want: 64},
+		{value: "255.0.255.0", maxBits: 32, wantErr: true},
+		{value: "33", maxBits: 32, wantErr: true},
+		{value: "mask", maxBits: 32, wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := ParsePrefixLength(c.value, c.maxBits)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParsePrefixLength(%q): unexpected error state: %v", c.value, err)
+			continue
+		}
+		if !c.wantErr && got != c.want {
+			t.Errorf("ParsePrefixLength(%q): expected %d, got %d", c.value, c.want, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = ssoGroupNameValidator{}
+
+// SSOGroupName returns a validator that accepts the name of an identity
+// provider group: non-empty, at most 256 characters and without leading or
+// trailing whitespace.
+func SSOGroupName() validator.String {
+	return ssoGroupNameValidator{}
+}
+
+type ssoGroupNameValidator struct{}
+
+func (v ssoGroupNameValidator) Description(_ context.Context) string {
+	return "value must be a non-empty IdP group name of at most 256 characters without leading or trailing whitespace"
+}
+
+func (v ssoGroupNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ssoGroupNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	name := req.ConfigValue.ValueString()
+	if name == "" || len(name) > 256 || strings.TrimSpace(name) != name {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid SSO Group Name",
+			fmt.Sprintf("Attribute %s %s, got: %q.", req.Path, v.Description(ctx), name),
+		)
+	}
+}
@@ -0,0 +1,32 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSSOGroupNameValidator(t *testing.T) {
+	cases := map[string]bool{
+		"plant-1-operators":                        true,
+		"CN=Operators,OU=Groups,DC=example,DC=com": true,
+		"":            false,
+		" operators":  false,
+		"operators\n": false,
+	}
+
+	for value, valid := range cases {
+		resp := &validator.StringResponse{}
+		SSOGroupName().ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("sso_group"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("SSOGroupName(%q): expected valid=%t, got diagnostics: %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
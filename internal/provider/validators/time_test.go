@@ -0,0 +1,54 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRFC3339Validator(t *testing.T) {
+	cases := map[string]bool{
+		"2025-06-01T12:00:00Z":        true,
+		"2025-06-01T12:00:00.5+02:00": true,
+		"2025-06-01T12:00:00":         false,
+		"2025-06-01":                  false,
+		"01.06.2025 12:00":            false,
+	}
+
+	for value, valid := range cases {
+		resp := &validator.StringResponse{}
+		RFC3339().ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("expiration_timestamp"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("RFC3339(%q): expected valid=%t, got diagnostics: %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	cases := map[string]bool{
+		"72h":   true,
+		"1h30m": true,
+		"0s":    false,
+		"-1h":   false,
+		"3d":    false,
+	}
+
+	for value, valid := range cases {
+		resp := &validator.StringResponse{}
+		Duration().ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("duration"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("Duration(%q): expected valid=%t, got diagnostics: %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
@@ -27,3 +27,8 @@ type VaultAPIResponse struct {
 	Name              string  `json:"name"`
 	Description       *string `json:"description"`
 }
+
+// VaultIdentityModel maps the resource identity schema used by import blocks.
+type VaultIdentityModel struct {
+	VaultID types.String `tfsdk:"vault_id"`
+}
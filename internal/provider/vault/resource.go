@@ -5,15 +5,21 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 
 	"github.com/sda/terraform-provider-sda/internal/clients"
+	"github.com/sda/terraform-provider-sda/internal/provider/importid"
 )
 
 var _ resource.Resource = &VaultResource{}
 var _ resource.ResourceWithImportState = &VaultResource{}
+var _ resource.ResourceWithIdentity = &VaultResource{}
+
+// importLookup resolves vault:<name> and group:<name>/vault:<name> import IDs.
+var importLookup = importid.Collection{Kind: "vault", Path: "/assets/v1/vault", IDField: "vault_id", Parent: &importid.ResourceGroups, ParentField: "group_id"}
 
 func NewVaultResource() resource.Resource {
 	return &VaultResource{}
@@ -87,6 +93,17 @@ func (r *VaultResource) Configure(_ context.Context, req resource.ConfigureReque
 	r.client = req.ProviderData.(*clients.Client)
 }
 
+func (r *VaultResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"vault_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Unique identifier for the vault.",
+			},
+		},
+	}
+}
+
 func (r *VaultResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("vault_id"), req, resp)
+	importid.ImportState(ctx, r.client, importLookup, path.Root("vault_id"), req, resp)
 }
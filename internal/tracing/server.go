@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sda/terraform-provider-sda/internal/clients"
+)
+
+// Span attributes of provider operations.
+const (
+	ResourceTypeKey   = attribute.Key("sda.resource_type")
+	DataSourceTypeKey = attribute.Key("sda.data_source_type")
+	OperationKey      = attribute.Key("sda.operation")
+	AssetIDKey        = attribute.Key("sda.asset_id")
+)
+
+// server records a span per resource and data source operation around the
+// provider server it wraps. The span is in the context of the operation, so
+// the HTTP calls of the operation are recorded as its children.
+type server struct {
+	tfprotov6.ProviderServer
+
+	once       sync.Once
+	states     map[string]*tfprotov6.Schema
+	identities map[string]*tfprotov6.ResourceIdentitySchema
+}
+
+// Server returns ps with a span recorded per operation.
+func Server(ps tfprotov6.ProviderServer) tfprotov6.ProviderServer {
+	return &server{ProviderServer: ps}
+}
+
+// ConfigureProvider implements tfprotov6.ProviderServer. Its span holds the
+// sign in of the provider.
+func (s *server) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	ctx, span := start(ctx, "sda configure", OperationKey.String("configure"))
+	resp, err := s.ProviderServer.ConfigureProvider(ctx, req)
+	if resp != nil {
+		recordDiagnostics(span, resp.Diagnostics)
+	}
+	end(span, err)
+	return resp, err
+}
+
+// ReadResource implements tfprotov6.ProviderServer.
+func (s *server) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, span := s.startResource(ctx, req.TypeName, "read", req.CurrentIdentity)
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	if resp != nil {
+		recordDiagnostics(span, resp.Diagnostics)
+	}
+	end(span, err)
+	return resp, err
+}
+
+// PlanResourceChange implements tfprotov6.ProviderServer.
+func (s *server) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx, span := s.startResource(ctx, req.TypeName, "plan", req.PriorIdentity)
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		recordDiagnostics(span, resp.Diagnostics)
+	}
+	end(span, err)
+	return resp, err
+}
+
+// ApplyResourceChange implements tfprotov6.ProviderServer. The operation is
+// create, update or delete, and the asset ID of a created resource is only
+// known once it is applied.
+func (s *server) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	operation := "update"
+	switch {
+	case s.isNull(ctx, req.TypeName, req.PriorState):
+		operation = "create"
+	case s.isNull(ctx, req.TypeName, req.PlannedState):
+		operation = "delete"
+	}
+
+	ctx, span := s.startResource(ctx, req.TypeName, operation, req.PlannedIdentity)
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		if id := s.assetID(ctx, req.TypeName, resp.NewIdentity); id != "" {
+			span.SetAttributes(AssetIDKey.String(id))
+		}
+		recordDiagnostics(span, resp.Diagnostics)
+	}
+	end(span, err)
+	return resp, err
+}
+
+// ImportResourceState implements tfprotov6.ProviderServer.
+func (s *server) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, span := s.startResource(ctx, req.TypeName, "import", req.Identity)
+	if req.ID != "" {
+		span.SetAttributes(AssetIDKey.String(req.ID))
+	}
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		recordDiagnostics(span, resp.Diagnostics)
+	}
+	end(span, err)
+	return resp, err
+}
+
+// ReadDataSource implements tfprotov6.ProviderServer.
+func (s *server) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, span := start(ctx, req.TypeName+" read", DataSourceTypeKey.String(req.TypeName), OperationKey.String("read"))
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		recordDiagnostics(span, resp.Diagnostics)
+	}
+	end(span, err)
+	return resp, err
+}
+
+// startResource starts the span of a resource operation, tagged with the
+// asset ID of identity when known.
+func (s *server) startResource(ctx context.Context, typeName, operation string, identity *tfprotov6.ResourceIdentityData) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{ResourceTypeKey.String(typeName), OperationKey.String(operation)}
+	if id := s.assetID(ctx, typeName, identity); id != "" {
+		attrs = append(attrs, AssetIDKey.String(id))
+	}
+	return start(ctx, typeName+" "+operation, attrs...)
+}
+
+// start starts a span of the provider tracer.
+func start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(clients.TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// end records err on span and ends it.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// recordDiagnostics records the error diagnostics of an operation on span.
+func recordDiagnostics(span trace.Span, diags []*tfprotov6.Diagnostic) {
+	for _, d := range diags {
+		if d == nil || d.Severity != tfprotov6.DiagnosticSeverityError {
+			continue
+		}
+		span.AddEvent("diagnostic", trace.WithAttributes(
+			attribute.String("summary", d.Summary),
+			attribute.String("detail", d.Detail),
+		))
+		span.SetStatus(codes.Error, d.Summary)
+	}
+}
+
+// loadSchemas reads the state and identity schemas of the resources once.
+func (s *server) loadSchemas(ctx context.Context) {
+	s.once.Do(func() {
+		if resp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{}); err == nil && resp != nil {
+			s.states = resp.ResourceSchemas
+		}
+		if resp, err := s.ProviderServer.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{}); err == nil && resp != nil {
+			s.identities = resp.IdentitySchemas
+		}
+	})
+}
+
+// isNull reports whether state, a state of the resource typeName, is null as
+// before a create or after a delete.
+func (s *server) isNull(ctx context.Context, typeName string, state *tfprotov6.DynamicValue) bool {
+	if state == nil {
+		return true
+	}
+	s.loadSchemas(ctx)
+	schema, ok := s.states[typeName]
+	if !ok {
+		return false
+	}
+	v, err := state.Unmarshal(schema.ValueType())
+	return err == nil && v.IsNull()
+}
+
+// assetID returns the attributes of identity that identify the asset on
+// import, joined with "/", or an empty string when unknown.
+func (s *server) assetID(ctx context.Context, typeName string, identity *tfprotov6.ResourceIdentityData) string {
+	if identity == nil || identity.IdentityData == nil {
+		return ""
+	}
+	s.loadSchemas(ctx)
+	schema, ok := s.identities[typeName]
+	if !ok {
+		return ""
+	}
+	v, err := identity.IdentityData.Unmarshal(schema.ValueType())
+	if err != nil || !v.IsKnown() || v.IsNull() {
+		return ""
+	}
+	var attrs map[string]tftypes.Value
+	if err := v.As(&attrs); err != nil {
+		return ""
+	}
+
+	var parts []string
+	for _, a := range schema.IdentityAttributes {
+		attr, ok := attrs[a.Name]
+		if !ok || !a.RequiredForImport || !attr.Type().Is(tftypes.String) || !attr.IsKnown() {
+			continue
+		}
+		var part string
+		if err := attr.As(&part); err == nil && part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeServer is a provider server with a single resource, sda_device.
+type fakeServer struct {
+	tfprotov6.ProviderServer
+	apply func(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) *tfprotov6.ApplyResourceChangeResponse
+}
+
+var (
+	stateType    = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"device_id": tftypes.String}}
+	identityType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"device_id": tftypes.String, "tenant_id": tftypes.String}}
+)
+
+func (f *fakeServer) GetProviderSchema(context.Context, *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return &tfprotov6.GetProviderSchemaResponse{ResourceSchemas: map[string]*tfprotov6.Schema{
+		"sda_device": {Block: &tfprotov6.SchemaBlock{Attributes: []*tfprotov6.SchemaAttribute{
+			{Name: "device_id", Type: tftypes.String, Computed: true},
+		}}},
+	}}, nil
+}
+
+func (f *fakeServer) GetResourceIdentitySchemas(context.Context, *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	return &tfprotov6.GetResourceIdentitySchemasResponse{IdentitySchemas: map[string]*tfprotov6.ResourceIdentitySchema{
+		"sda_device": {IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+			{Name: "device_id", Type: tftypes.String, RequiredForImport: true},
+			{Name: "tenant_id", Type: tftypes.String, OptionalForImport: true},
+		}},
+	}}, nil
+}
+
+func (f *fakeServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	return f.apply(ctx, req), nil
+}
+
+func dynamicValue(t *testing.T, typ tftypes.Type, v tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	dv, err := tfprotov6.NewDynamicValue(typ, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+func TestServer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(previous)
+
+	device := tftypes.NewValue(stateType, map[string]tftypes.Value{"device_id": tftypes.NewValue(tftypes.String, "d1")})
+	identity := tftypes.NewValue(identityType, map[string]tftypes.Value{
+		"device_id": tftypes.NewValue(tftypes.String, "d1"),
+		"tenant_id": tftypes.NewValue(tftypes.String, nil),
+	})
+
+	var inOperation bool
+	fake := &fakeServer{apply: func(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) *tfprotov6.ApplyResourceChangeResponse {
+		inOperation = trace.SpanFromContext(ctx).SpanContext().IsValid()
+		if req.PriorState != nil {
+			return &tfprotov6.ApplyResourceChangeResponse{Diagnostics: []*tfprotov6.Diagnostic{
+				{Severity: tfprotov6.DiagnosticSeverityError, Summary: "API Error"},
+			}}
+		}
+		return &tfprotov6.ApplyResourceChangeResponse{
+			NewState:    dynamicValue(t, stateType, device),
+			NewIdentity: &tfprotov6.ResourceIdentityData{IdentityData: dynamicValue(t, identityType, identity)},
+		}
+	}}
+	s := Server(fake)
+
+	s.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "sda_device",
+		PlannedState: dynamicValue(t, stateType, tftypes.NewValue(stateType, map[string]tftypes.Value{"device_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)})),
+	})
+	if !inOperation {
+		t.Fatal("the operation context has no span")
+	}
+	s.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:        "sda_device",
+		PriorState:      dynamicValue(t, stateType, device),
+		PlannedState:    dynamicValue(t, stateType, tftypes.NewValue(stateType, nil)),
+		PlannedIdentity: &tfprotov6.ResourceIdentityData{IdentityData: dynamicValue(t, identityType, identity)},
+	})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	for i, want := range []struct {
+		name string
+		code codes.Code
+	}{
+		{name: "sda_device create", code: codes.Unset},
+		{name: "sda_device delete", code: codes.Error},
+	} {
+		span := spans[i]
+		if span.Name != want.name || span.Status.Code != want.code {
+			t.Errorf("span %d = %q with status %v, want %q with status %v", i, span.Name, span.Status.Code, want.name, want.code)
+		}
+		var assetID string
+		for _, attr := range span.Attributes {
+			if attr.Key == AssetIDKey {
+				assetID = attr.Value.AsString()
+			}
+		}
+		if assetID != "d1" {
+			t.Errorf("span %d asset ID = %q, want d1", i, assetID)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Package tracing implements the optional OpenTelemetry tracing of the
+// provider. Tracing is enabled by OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, and exports spans over OTLP/HTTP. The
+// other OTEL_EXPORTER_OTLP_* environment variables, such as the headers,
+// apply as usual.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// serviceName is the OpenTelemetry service name of the provider.
+const serviceName = "terraform-provider-sda"
+
+// Enabled reports whether an OTLP endpoint is configured.
+func Enabled(getenv func(string) string) bool {
+	return getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs a global tracer provider exporting to the configured OTLP
+// endpoint. It returns nil when tracing is not enabled, and otherwise a
+// function that flushes the remaining spans and stops the exporter.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	if !Enabled(os.Getenv) {
+		return nil, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
@@ -4,27 +4,72 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/sda/terraform-provider-sda/internal/clients"
 	"github.com/sda/terraform-provider-sda/internal/provider"
+	"github.com/sda/terraform-provider-sda/internal/tracing"
 )
 
 var (
 	version string = "0.1.0"
 )
 
+// Terraform kills the provider about two seconds after it stops it, so the
+// work done on shutdown is bounded to finish before.
+const (
+	signOutTimeout  = time.Second
+	shutdownTimeout = 1500 * time.Millisecond
+)
+
 func main() {
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/softwaredefinedautomation/sda",
-		Debug:   debug,
+	shutdownTracing, err := tracing.Setup(context.Background(), version)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve(
+		"registry.terraform.io/softwaredefinedautomation/sda",
+		func() tfprotov6.ProviderServer {
+			server := providerserver.NewProtocol6(provider.New(version)())()
+			if shutdownTracing != nil {
+				server = tracing.Server(server)
+			}
+			return server
+		},
+		serveOpts...,
+	)
+
+	// Terraform stops the provider once it is done with it, so the sessions
+	// it signed in are no longer needed. Signing out and exporting the
+	// remaining spans are best-effort: what does not finish before the
+	// deadline is dropped, and the sessions expire with their tokens.
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	signOutCtx, cancelSignOut := context.WithTimeout(ctx, signOutTimeout)
+	clients.SignOutAll(signOutCtx)
+	cancelSignOut()
+
+	if shutdownTracing != nil {
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("Unable to export the remaining traces: %s", err)
+		}
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 	if err != nil {
 		log.Fatal(err)
 	}